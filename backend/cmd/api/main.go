@@ -14,6 +14,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
+	"nearline/backend/internal/domain"
 	"nearline/backend/internal/handler/admin"
 	client_handler "nearline/backend/internal/handler/client"
 	"nearline/backend/internal/infra/auth"
@@ -56,18 +57,26 @@ func run() error {
 	qRepo := repository_impl.NewQuestionRepository(client)
 	aRepo := repository_impl.NewAttemptRepository(client)
 	sRepo := repository_impl.NewUserStatsRepository(client)
+	qsRepo := repository_impl.NewQuestionStatsRepository(client)
+	rRepo := repository_impl.NewReviewItemRepository(client)
 	txRepo := repository_impl.NewTransactionRepository(client)
 	examRepo := repository_impl.NewExamRepository(client)
 	userRepo := repository_impl.NewUserRepository(client)
 
-	questionUsecase := usecase.NewQuestionUsecase(qRepo)
-	attemptUsecase := usecase.NewAttemptUsecase(qRepo, aRepo, sRepo, txRepo)
-	statsUsecase := usecase.NewStatsUsecase(sRepo)
+	accessPolicy := usecase.NewAccessPolicy(userRepo)
+
+	questionUsecase := usecase.NewQuestionUsecase(examRepo, qRepo, txRepo, accessPolicy)
+	attemptUsecase := usecase.NewAttemptUsecase(examRepo, qRepo, aRepo, sRepo, qsRepo, rRepo, txRepo, accessPolicy)
+	statsUsecase := usecase.NewStatsUsecase(sRepo, aRepo)
 	examUsecase := usecase.NewExamUsecase(examRepo, qRepo, aRepo, sRepo, txRepo)
 	userUsecase := usecase.NewUserUsecase(userRepo)
+	statsRebuildUsecase := usecase.NewStatsRebuildUsecase(userRepo, aRepo, qRepo, sRepo, txRepo)
+	itemAnalysisUsecase := usecase.NewItemAnalysisUsecase(qRepo, qsRepo)
+	reviewUsecase := usecase.NewReviewUsecase(qRepo, rRepo, accessPolicy)
+	mistakeUsecase := usecase.NewMistakeUsecase(aRepo, qRepo, accessPolicy)
 
-	adminHandler := admin.NewAdminHandler(questionUsecase)
-	clientHandler := client_handler.NewClientHandler(questionUsecase, attemptUsecase, statsUsecase, examUsecase, userUsecase)
+	adminHandler := admin.NewAdminHandler(questionUsecase, statsRebuildUsecase, itemAnalysisUsecase, examUsecase)
+	clientHandler := client_handler.NewClientHandler(questionUsecase, attemptUsecase, statsUsecase, examUsecase, userUsecase, reviewUsecase, mistakeUsecase)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -86,11 +95,31 @@ func run() error {
 
 	// 認証ミドルウェア
 	authMiddleware := internal_middleware.AuthMiddleware(authClient)
+	// 認可ミドルウェア (authMiddleware の後に適用する)
+	requireAdmin := internal_middleware.RequireRole(userUsecase, domain.RoleAdmin)
 
 	// 管理者用ルート
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(authMiddleware)
+		r.Use(requireAdmin)
+		r.Get("/exams", adminHandler.ListExams)
+		r.Post("/exams", adminHandler.CreateExam)
+		r.Put("/exams/order", adminHandler.ReorderExams)
+		r.Patch("/exams/{examID}", adminHandler.UpdateExam)
+		r.Post("/exams/{examID}/retire", adminHandler.RetireExam)
+		r.Post("/exams/{examID}/sets", adminHandler.CreateExamSet)
+		r.Patch("/exams/{examID}/sets/{examSetID}", adminHandler.UpdateExamSet)
+		r.Delete("/exams/{examID}/sets/{examSetID}", adminHandler.DeleteExamSet)
 		r.Post("/exams/{examID}/sets/{examSetID}/questions", adminHandler.UploadQuestions)
+		r.Post("/exams/{examID}/sets/{examSetID}/question-ids", adminHandler.AddExamSetQuestions)
+		r.Put("/exams/{examID}/sets/{examSetID}/question-ids", adminHandler.ReorderExamSetQuestions)
+		r.Delete("/exams/{examID}/sets/{examSetID}/question-ids/{questionID}", adminHandler.RemoveExamSetQuestion)
+		r.Post("/exams/{examID}/stats/rebuild", adminHandler.RebuildStats)
+		r.Get("/exams/{examID}/item-analysis", adminHandler.GetItemAnalysis)
+		r.Get("/questions/{questionID}", adminHandler.GetQuestion)
+		r.Patch("/questions/{questionID}", adminHandler.UpdateQuestion)
+		r.Delete("/questions/{questionID}", adminHandler.DeleteQuestion)
+		r.Get("/questions/{questionID}/revisions", adminHandler.GetQuestionRevisions)
 	})
 
 	// Exams (Public & Protected mixed)
@@ -116,10 +145,22 @@ func run() error {
 			r.Post("/", clientHandler.CreateUser)
 			r.Get("/me", clientHandler.GetCurrentUser)
 			r.Route("/me", func(r chi.Router) {
+				r.Get("/attempts", clientHandler.ListAttempts)
 				r.Post("/attempts", clientHandler.StartAttempt)
+				r.Get("/attempts/{attemptID}", clientHandler.GetAttempt)
 				r.Put("/attempts/{attemptID}", clientHandler.UpdateAttempt)
 				r.Post("/attempts/{attemptID}/complete", clientHandler.CompleteAttempt)
+				r.Post("/attempts/{attemptID}/pause", clientHandler.PauseAttempt)
+				r.Post("/attempts/{attemptID}/resume", clientHandler.ResumeAttempt)
+				r.Post("/attempts/{attemptID}/abandon", clientHandler.AbandonAttempt)
+				r.Get("/attempts/{attemptID}/questions", clientHandler.GetAttemptQuestions)
+				r.Get("/attempts/{attemptID}/answers", clientHandler.GetAttemptAnswers)
+				r.Get("/attempts/{attemptID}/review", clientHandler.GetAttemptReview)
 				r.Get("/stats/{examID}", clientHandler.GetStats)
+				r.Get("/stats/{examID}/trend", clientHandler.GetScoreTrend)
+				r.Get("/review-queue", clientHandler.GetReviewQueue)
+				r.Post("/review-queue/{questionID}", clientHandler.RecordReview)
+				r.Get("/mistakes", clientHandler.ListMistakes)
 			})
 		})
 	})
@@ -157,7 +198,7 @@ func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Set CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*") // In production, replace * with specific origin
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 		// Handle preflight requests
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"nearline/backend/internal/infra/firestore"
+	"nearline/backend/internal/repository_impl"
+	"nearline/backend/internal/usecase"
+	"nearline/backend/internal/usecase/input"
+
+	"github.com/joho/godotenv"
+)
+
+// 完了済みの受験履歴から、ユーザーの試験ごとの累積成績 (users/{uid}/stats/{examID}) を再集計します。
+//
+// 使い方:
+//
+//	go run ./cmd/rebuild_stats -exam professional-cloud-developer -dry-run
+//	go run ./cmd/rebuild_stats -exam professional-cloud-developer -user <uid>
+func main() {
+	examID := flag.String("exam", "", "再集計する試験ID (必須)")
+	userID := flag.String("user", "", "再集計するユーザーID。省略時は全ユーザー")
+	dryRun := flag.Bool("dry-run", false, "保存せずに差分のみを表示する")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println(".env ファイルが見つかりません。環境変数に依存します。")
+	}
+
+	in, err := input.NewRebuildStats(*examID, *userID, *dryRun)
+	if err != nil {
+		flag.Usage()
+		log.Fatalf("引数が不正です: %v", err)
+	}
+
+	ctx := context.Background()
+	client := firestore.NewClient(ctx)
+	defer client.Close()
+
+	rebuildUsecase := usecase.NewStatsRebuildUsecase(
+		repository_impl.NewUserRepository(client),
+		repository_impl.NewAttemptRepository(client),
+		repository_impl.NewQuestionRepository(client),
+		repository_impl.NewUserStatsRepository(client),
+		repository_impl.NewTransactionRepository(client),
+	)
+
+	result, err := rebuildUsecase.RebuildStats(ctx, in)
+	if err != nil {
+		log.Fatalf("統計の再集計に失敗しました: %+v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result.Users); err != nil {
+		log.Fatalf("結果の出力に失敗しました: %v", err)
+	}
+
+	if result.DryRun {
+		fmt.Printf("[dry-run] %d 人中 %d 人の統計が変更されます (保存はしていません)\n", result.ScannedUsers, result.ChangedUsers)
+		return
+	}
+	fmt.Printf("%d 人中 %d 人の統計を更新しました\n", result.ScannedUsers, result.ChangedUsers)
+}
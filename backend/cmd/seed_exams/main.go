@@ -4,14 +4,20 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"time"
 
 	"nearline/backend/internal/domain"
 	"nearline/backend/internal/infra/firestore"
+	"nearline/backend/internal/repository_impl"
+	"nearline/backend/internal/usecase"
+	"nearline/backend/internal/usecase/input"
 
+	"github.com/cockroachdb/errors"
 	"github.com/joho/godotenv"
 )
 
+// 初期データとして試験を登録します。登録済みの試験は上書きしません。
+// 登録済みの試験のうち、試験コードが予約されていないものは予約します。
+// 試験の追加・変更は管理APIで行い、ここには初期データのみを記載します。
 func main() {
 	// Load .env file
 	if err := godotenv.Load(); err != nil {
@@ -22,88 +28,118 @@ func main() {
 	client := firestore.NewClient(ctx)
 	defer client.Close()
 
-	exams := []domain.Exam{
+	exams := []input.CreateExamRequest{
 		{
-			ID:          "cloud-digital-leader",
-			Code:        "CDL",
-			Name:        "Cloud Digital Leader",
-			Description: "Google Cloud のコアプロダクトとサービスに関する知識、およびそれらが組織にどのように利益をもたらすかを理解していることを示します。",
-			ImageURL:    "/images/exams/cdl.png",
-			CreatedAt:   time.Now(),
+			ID:              "cloud-digital-leader",
+			Code:            "CDL",
+			Name:            "Cloud Digital Leader",
+			Description:     "Google Cloud のコアプロダクトとサービスに関する知識、およびそれらが組織にどのように利益をもたらすかを理解していることを示します。",
+			ImageURL:        "/images/exams/cdl.png",
+			DurationMinutes: 90,
+			PassingScore:    70,
 		},
 		{
-			ID:          "associate-cloud-engineer",
-			Code:        "ACE",
-			Name:        "Associate Cloud Engineer",
-			Description: "アプリケーションのデプロイ、オペレーションのモニタリング、エンタープライズ ソリューションの管理を行います。",
-			ImageURL:    "/images/exams/ace.png",
-			CreatedAt:   time.Now(),
+			ID:              "associate-cloud-engineer",
+			Code:            "ACE",
+			Name:            "Associate Cloud Engineer",
+			Description:     "アプリケーションのデプロイ、オペレーションのモニタリング、エンタープライズ ソリューションの管理を行います。",
+			ImageURL:        "/images/exams/ace.png",
+			DurationMinutes: 120,
+			PassingScore:    70,
 		},
 		{
-			ID:          "professional-cloud-architect",
-			Code:        "PCA",
-			Name:        "Professional Cloud Architect",
-			Description: "Google Cloud 技術を活用した、安全でスケーラブル、かつ可用性の高い堅牢なソリューションを設計、開発、管理する能力を評価します。",
-			ImageURL:    "/images/exams/pca.png",
-			CreatedAt:   time.Now(),
+			ID:              "professional-cloud-architect",
+			Code:            "PCA",
+			Name:            "Professional Cloud Architect",
+			Description:     "Google Cloud 技術を活用した、安全でスケーラブル、かつ可用性の高い堅牢なソリューションを設計、開発、管理する能力を評価します。",
+			ImageURL:        "/images/exams/pca.png",
+			DurationMinutes: 120,
+			PassingScore:    70,
 		},
 		{
-			ID:          "professional-cloud-developer",
-			Code:        "PCD",
-			Name:        "Professional Cloud Developer",
-			Description: "スケーラブルで可用性の高いアプリケーションを構築、デプロイ、管理する能力を評価します。",
-			ImageURL:    "/images/exams/pcd.png",
-			CreatedAt:   time.Now(),
+			ID:              "professional-cloud-developer",
+			Code:            "PCD",
+			Name:            "Professional Cloud Developer",
+			Description:     "スケーラブルで可用性の高いアプリケーションを構築、デプロイ、管理する能力を評価します。",
+			ImageURL:        "/images/exams/pcd.png",
+			DurationMinutes: 120,
+			PassingScore:    70,
 		},
 		{
-			ID:          "professional-data-engineer",
-			Code:        "PDE",
-			Name:        "Professional Data Engineer",
-			Description: "データの収集、変換、公開によって、データ主導の意思決定を可能にします。",
-			ImageURL:    "/images/exams/pde.png",
-			CreatedAt:   time.Now(),
+			ID:              "professional-data-engineer",
+			Code:            "PDE",
+			Name:            "Professional Data Engineer",
+			Description:     "データの収集、変換、公開によって、データ主導の意思決定を可能にします。",
+			ImageURL:        "/images/exams/pde.png",
+			DurationMinutes: 120,
+			PassingScore:    70,
 		},
 		{
-			ID:          "professional-cloud-devops-engineer",
-			Code:        "PDOE",
-			Name:        "Professional Cloud DevOps Engineer",
-			Description: "効率的な開発運用パイプラインを構築し、サービスの信頼性を維持する能力を評価します。",
-			ImageURL:    "/images/exams/pdoe.png",
-			CreatedAt:   time.Now(),
+			ID:              "professional-cloud-devops-engineer",
+			Code:            "PDOE",
+			Name:            "Professional Cloud DevOps Engineer",
+			Description:     "効率的な開発運用パイプラインを構築し、サービスの信頼性を維持する能力を評価します。",
+			ImageURL:        "/images/exams/pdoe.png",
+			DurationMinutes: 120,
+			PassingScore:    70,
 		},
 		{
-			ID:          "professional-cloud-security-engineer",
-			Code:        "PCSE",
-			Name:        "Professional Cloud Security Engineer",
-			Description: "Google Cloud 上で安全なインフラストラクチャを設計、実装する能力を評価します。",
-			ImageURL:    "/images/exams/pcse.png",
-			CreatedAt:   time.Now(),
+			ID:              "professional-cloud-security-engineer",
+			Code:            "PCSE",
+			Name:            "Professional Cloud Security Engineer",
+			Description:     "Google Cloud 上で安全なインフラストラクチャを設計、実装する能力を評価します。",
+			ImageURL:        "/images/exams/pcse.png",
+			DurationMinutes: 120,
+			PassingScore:    70,
 		},
 		{
-			ID:          "professional-cloud-network-engineer",
-			Code:        "PCNE",
-			Name:        "Professional Cloud Network Engineer",
-			Description: "Google Cloud 上でネットワーク アーキテクチャを実装、管理する能力を評価します。",
-			ImageURL:    "/images/exams/pcne.png",
-			CreatedAt:   time.Now(),
+			ID:              "professional-cloud-network-engineer",
+			Code:            "PCNE",
+			Name:            "Professional Cloud Network Engineer",
+			Description:     "Google Cloud 上でネットワーク アーキテクチャを実装、管理する能力を評価します。",
+			ImageURL:        "/images/exams/pcne.png",
+			DurationMinutes: 120,
+			PassingScore:    70,
 		},
 		{
-			ID:          "professional-machine-learning-engineer",
-			Code:        "PMLE",
-			Name:        "Professional Machine Learning Engineer",
-			Description: "ML モデルの構築、評価、本番環境へのデプロイ、および最適化を行う能力を評価します。",
-			ImageURL:    "/images/exams/pmle.png",
-			CreatedAt:   time.Now(),
+			ID:              "professional-machine-learning-engineer",
+			Code:            "PMLE",
+			Name:            "Professional Machine Learning Engineer",
+			Description:     "ML モデルの構築、評価、本番環境へのデプロイ、および最適化を行う能力を評価します。",
+			ImageURL:        "/images/exams/pmle.png",
+			DurationMinutes: 120,
+			PassingScore:    70,
 		},
 	}
 
-	for _, exam := range exams {
-		_, err := client.Collection("exams").Doc(exam.ID).Set(ctx, exam)
+	examUsecase := usecase.NewExamUsecase(
+		repository_impl.NewExamRepository(client),
+		repository_impl.NewQuestionRepository(client),
+		repository_impl.NewAttemptRepository(client),
+		repository_impl.NewUserStatsRepository(client),
+		repository_impl.NewTransactionRepository(client),
+	)
+
+	for _, req := range exams {
+		in, err := input.NewCreateExam(req)
 		if err != nil {
-			log.Printf("Failed to seed exam %s: %v\n", exam.Name, err)
-		} else {
-			fmt.Printf("Seeded exam: %s\n", exam.Name)
+			log.Printf("Invalid exam %s: %v\n", req.Name, err)
+			continue
+		}
+		if _, err := examUsecase.CreateExam(ctx, in); err != nil {
+			if errors.Is(err, domain.ErrAlreadyExists) {
+				fmt.Printf("Skipped existing exam: %s\n", req.Name)
+				continue
+			}
+			log.Printf("Failed to seed exam %s: %v\n", req.Name, err)
+			continue
 		}
+		fmt.Printf("Seeded exam: %s\n", req.Name)
+	}
+
+	// 試験コードの予約を導入する前に登録された試験にも予約を補い、以後の試験コードの重複を防ぐ
+	if err := examUsecase.ReserveExamCodes(ctx); err != nil {
+		log.Printf("Failed to reserve exam codes: %v\n", err)
 	}
 
 	fmt.Println("Seeding completed.")
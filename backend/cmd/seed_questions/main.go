@@ -12,6 +12,7 @@ import (
 
 	"nearline/backend/internal/domain"
 	"nearline/backend/internal/infra/firestore"
+	"nearline/backend/internal/util"
 
 	"github.com/joho/godotenv"
 	"github.com/samber/lo"
@@ -108,7 +109,7 @@ func main() {
 		hasError := false
 
 		// 4-1. 問題タイプに基づくチェック
-		if q.QuestionType == "multiple-choice" && len(correctAnswers) > 1 {
+		if q.QuestionType == domain.QuestionTypeMultipleChoice && len(correctAnswers) > 1 {
 			log.Printf("エラー: 問題 %d は multiple-choice ですが、正解が複数あります: %s", i, q.CorrectAnswers)
 			hasError = true
 		}
@@ -117,7 +118,7 @@ func main() {
 		for _, ans := range correctAnswers {
 			num, err := strconv.Atoi(ans)
 			if err != nil {
-				log.Printf("エラー: 問題 %d の正解 '%s' が数値ではありません。", i, ans, i)
+				log.Printf("エラー: 問題 %d の正解 '%s' が数値ではありません。", i, ans)
 				hasError = true
 				break
 			}
@@ -191,13 +192,19 @@ func main() {
 		setID := fmt.Sprintf("practice_exam_%d", setIndex+1) 
 		log.Printf("セット %d (ID: %s) の %d 問を処理中...", setIndex+1, setID, len(chunk))
 
+		for qIndex, q := range chunk {
+			// 問題 ID の生成: ExamCode_SetIndex_QuestionIndex (例: PCD_SET1_001)
+			q.ID = fmt.Sprintf("%s_SET%d_%03d", TargetExamCode, setIndex+1, qIndex+1)
+			q.ExamSetID = setID
+		}
+
 		// ExamSet ドキュメントの作成
 		examSet := domain.ExamSet{
 			ID:          setID,
 			ExamID:      TargetExamID,
 			Name:        fmt.Sprintf("Practice Exam %d", setIndex+1),
 			Description: fmt.Sprintf("%d questions covering all domains", len(chunk)),
-			QuestionIDs: []string{}, // 後で埋めることも可能だが、ここでは省略
+			QuestionIDs: util.Map(chunk, func(q *domain.Question) string { return q.ID }), // 出題順
 			CreatedAt:   time.Now(),
 		}
 		
@@ -208,11 +215,7 @@ func main() {
 			log.Printf("エラー: ExamSet の保存に失敗しました (ID: %s): %v", setID, err)
 		}
 
-		for qIndex, q := range chunk {
-			// 問題 ID の生成: ExamCode_SetIndex_QuestionIndex (例: PCD_SET1_001)
-			q.ID = fmt.Sprintf("%s_SET%d_%03d", TargetExamCode, setIndex+1, qIndex+1)
-			q.ExamSetID = setID
-
+		for _, q := range chunk {
 			// exams/{examID}/sets/{setID}/questions/{questionID} に保存
 			docRef := setRef.Collection("questions").Doc(q.ID)
 			_, err := bulkWriter.Set(docRef, q)
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+)
+
+// FreeExamIDs は無料ユーザーでも受験可能な試験IDのリストです。
+var FreeExamIDs = []string{"cloud-digital-leader"}
+
+// AccessDeniedReason は試験へのアクセスが拒否された理由を表す機械可読なコードです。
+// フロントエンドはこの値を見てアップグレード導線などを出し分けます。
+//
+// tygo:enum
+type AccessDeniedReason string
+
+const (
+	ReasonUserNotRegistered    AccessDeniedReason = "user_not_registered"   // ユーザー登録が完了していない
+	ReasonProRequired          AccessDeniedReason = "pro_required"          // Proプランへの加入が必要
+	ReasonSubscriptionInactive AccessDeniedReason = "subscription_inactive" // Proプランの有効期限切れ・解約済み
+)
+
+// AccessDeniedError は試験へのアクセス拒否を表すエラーです。
+// errors.Is(err, ErrPermissionDenied) で判定できます。
+type AccessDeniedError struct {
+	Reason AccessDeniedReason
+	ExamID string
+}
+
+func (e *AccessDeniedError) Error() string {
+	return fmt.Sprintf("試験 %s へのアクセス権限がありません (%s)", e.ExamID, e.Reason)
+}
+
+// Is は ErrPermissionDenied との比較を可能にします。
+func (e *AccessDeniedError) Is(target error) bool {
+	return target == ErrPermissionDenied
+}
+
+// NewAccessDeniedError は新しいAccessDeniedErrorを生成します。
+func NewAccessDeniedError(reason AccessDeniedReason, examID string) *AccessDeniedError {
+	return &AccessDeniedError{Reason: reason, ExamID: examID}
+}
+
+// IsFreeExam は指定された試験が無料で受験可能かどうかを返します。
+func IsFreeExam(examID string) bool {
+	return lo.Contains(FreeExamIDs, examID)
+}
+
+// HasActiveSubscription はユーザーが有効なProプランに加入しているかどうかを返します。
+func (u *User) HasActiveSubscription() bool {
+	return u.Role == RolePro && u.SubscriptionStatus == SubActive
+}
+
+// CheckExamAccess はユーザーが指定された試験にアクセス可能かを判定します。
+// アクセスできない場合は理由付きの AccessDeniedError を返します。
+//
+//   - admin: すべての試験にアクセス可能
+//   - pro (active): すべての試験にアクセス可能
+//   - pro (expired / canceled): 無料ユーザーと同等
+//   - free: 無料試験のみアクセス可能
+func (u *User) CheckExamAccess(examID string) error {
+	if u.Role == RoleAdmin || u.HasActiveSubscription() {
+		return nil
+	}
+	if IsFreeExam(examID) {
+		return nil
+	}
+	if u.Role == RolePro {
+		return NewAccessDeniedError(ReasonSubscriptionInactive, examID)
+	}
+	return NewAccessDeniedError(ReasonProRequired, examID)
+}
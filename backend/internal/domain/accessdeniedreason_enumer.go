@@ -0,0 +1,18 @@
+// Code generated by go run scripts/gen_enum_methods.go; DO NOT EDIT.
+package domain
+
+func (AccessDeniedReason) Values() []string {
+	return []string{
+		"user_not_registered",
+		"pro_required",
+		"subscription_inactive",
+	}
+}
+
+func AccessDeniedReasonValues() []AccessDeniedReason {
+	return []AccessDeniedReason{
+		"user_not_registered",
+		"pro_required",
+		"subscription_inactive",
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// AdaptiveExamSetID は弱点分野から生成する個別の問題セットを表す ExamSetID です。
+	// このIDで開始した受験は、開始時に選ばれた問題を Attempt.QuestionIDs に保持します。
+	AdaptiveExamSetID = "adaptive"
+
+	DefaultAdaptiveQuestionCount = 20
+	MaxAdaptiveQuestionCount     = 100
+
+	// MasteredReviewBox は復習項目がこの箱以上にある問題を習得済みとみなす閾値です。
+	MasteredReviewBox = 4
+	// AdaptiveMasteryWindow は一度も間違えていない問題を、この期間内に回答していれば習得済みとみなす期間です。
+	AdaptiveMasteryWindow = 14 * 24 * time.Hour
+
+	// adaptiveBaseDomainWeight は正答率100%の分野にも一定の割合で出題するための重みの下限です。
+	adaptiveBaseDomainWeight = 0.1
+)
+
+// IsGenerated は弱点分野や間違いノートから個別に生成した問題セットの受験かどうかを返します。
+// 生成したセットの受験は、開始時に選ばれた問題を QuestionIDs に保持します。
+func (a *Attempt) IsGenerated() bool {
+	return a.ExamSetID == AdaptiveExamSetID || a.ExamSetID == MistakesExamSetID
+}
+
+// SelectAdaptiveQuestions は候補の問題から最大 n 問を、正答率の低い分野ほど多くなるように選びます。
+// 分野の重みは (1 - 正答率) に下限を加えた値で、まだ回答していない分野は正答率0%として扱います。
+// 分野ごとの出題数は重みに比例して配分し (Sainte-Laguë 方式)、分野の候補が足りない分は他の分野に回します。
+// stats が nil の場合はすべての分野を同じ重みで扱います。
+func SelectAdaptiveQuestions(candidates []Question, stats *UserExamStats, n int, rng *rand.Rand) []Question {
+	byDomain := lo.GroupBy(candidates, func(q Question) string { return q.Domain })
+	domains := lo.Keys(byDomain)
+	slices.Sort(domains)
+
+	weights := make(map[string]float64, len(domains))
+	for _, d := range domains {
+		accuracy := 0.0
+		if stats != nil {
+			if s, ok := stats.DomainStats[d]; ok && s.TotalCount > 0 {
+				accuracy = float64(s.CorrectCount) / float64(s.TotalCount)
+			}
+		}
+		weights[d] = 1 - accuracy + adaptiveBaseDomainWeight
+	}
+
+	allocation := make(map[string]int, len(domains))
+	for range min(n, len(candidates)) {
+		best := ""
+		bestQuotient := -1.0
+		for _, d := range domains {
+			if allocation[d] >= len(byDomain[d]) {
+				continue
+			}
+			if quotient := weights[d] / float64(2*allocation[d]+1); quotient > bestQuotient {
+				best, bestQuotient = d, quotient
+			}
+		}
+		allocation[best]++
+	}
+
+	selected := make([]Question, 0, min(n, len(candidates)))
+	for _, d := range domains {
+		pool := slices.Clone(byDomain[d])
+		rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+		selected = append(selected, pool[:allocation[d]]...)
+	}
+	rng.Shuffle(len(selected), func(i, j int) { selected[i], selected[j] = selected[j], selected[i] })
+	return selected
+}
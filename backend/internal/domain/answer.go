@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/samber/lo"
+
+	"nearline/backend/internal/util"
+)
+
+// ValidateAnswers は回答が試験セットの問題に対して妥当かを検証します。
+// 不正な回答がある場合は、問題ごとの理由を含む ValidationError を返します。
+//
+//   - 試験セットに含まれない問題IDへの回答は不可
+//   - 問題に存在しない選択肢IDは不可
+//   - 同じ選択肢の重複選択は不可
+//   - 単一選択問題で複数の選択肢を選ぶことは不可
+//
+// 空の回答は未回答への取り消しとして扱い、許可します。
+func ValidateAnswers(questions []Question, answers map[string][]string) error {
+	qMap := lo.KeyBy(questions, func(q Question) string {
+		return q.ID
+	})
+
+	verr := &ValidationError{}
+	questionIDs := lo.Keys(answers)
+	slices.Sort(questionIDs)
+
+	for _, qID := range questionIDs {
+		field := "answers." + qID
+		selected := answers[qID]
+
+		q, ok := qMap[qID]
+		if !ok {
+			verr.Add(field, "この試験セットに存在しない問題です")
+			continue
+		}
+
+		optionIDs := util.Map(q.Options, func(o AnswerOption) string {
+			return o.ID
+		})
+		for _, id := range lo.Uniq(selected) {
+			if !lo.Contains(optionIDs, id) {
+				verr.Add(field, fmt.Sprintf("存在しない選択肢です: %s", id))
+			}
+		}
+		if len(lo.Uniq(selected)) != len(selected) {
+			verr.Add(field, "同じ選択肢が重複しています")
+		}
+		if q.QuestionType != QuestionTypeMultiSelect && len(selected) > 1 {
+			verr.Add(field, "単一選択問題では選択肢を1つだけ選んでください")
+		}
+	}
+
+	return verr.ErrOrNil()
+}
+
+// ValidateTimeSpent は問題ごとの回答時間(秒)が試験セットの問題に対して妥当かを検証します。
+func ValidateTimeSpent(questions []Question, timeSpent map[string]int) error {
+	verr := &ValidationError{}
+	questionIDs := lo.Keys(timeSpent)
+	slices.Sort(questionIDs)
+
+	for _, qID := range questionIDs {
+		field := "timeSpent." + qID
+		if !lo.ContainsBy(questions, func(q Question) bool { return q.ID == qID }) {
+			verr.Add(field, "この試験セットに存在しない問題です")
+			continue
+		}
+		if timeSpent[qID] < 0 {
+			verr.Add(field, "回答時間は0以上で指定してください")
+		}
+	}
+
+	return verr.ErrOrNil()
+}
@@ -4,35 +4,61 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
 )
 
 // Attempt はユーザーの1回の受験データを表します。
 type Attempt struct {
-	ID             string              `json:"id" firestore:"id"`
-	UserID         string              `json:"userId" firestore:"user_id"`
-	ExamID         string              `json:"examId" firestore:"exam_id"`         // 資格ID
-	ExamSetID      string              `json:"examSetId" firestore:"exam_set_id"`  // 模擬試験セットID
-	Status         AttemptStatus       `json:"status" firestore:"status"`
-	Score          int                 `json:"score" firestore:"score"`
-	TotalQuestions int                 `json:"totalQuestions" firestore:"total_questions"`
-	CurrentIndex   int                 `json:"currentIndex" firestore:"current_index"`
-	Answers        map[string][]string `json:"answers" firestore:"answers"` // Key: QuestionID, Value: Selected Option IDs
-	StartedAt      time.Time           `json:"startedAt" firestore:"started_at"`
-	UpdatedAt      time.Time           `json:"updatedAt" firestore:"updated_at"`
-	CompletedAt    *time.Time          `firestore:"completed_at,omitempty"`
+	ID                 string              `json:"id" firestore:"id"`
+	UserID             string              `json:"userId" firestore:"user_id"`
+	ExamID             string              `json:"examId" firestore:"exam_id"`        // 資格ID
+	ExamSetID          string              `json:"examSetId" firestore:"exam_set_id"` // 模擬試験セットID
+	Mode               AttemptMode         `json:"mode" firestore:"mode"`
+	Status             AttemptStatus       `json:"status" firestore:"status"`
+	Score              int                 `json:"score" firestore:"score"`                                              // 正解数 (CorrectCount と同値。既存クライアントのため保持)
+	CorrectCount       int                 `json:"correctCount" firestore:"correct_count"`                               // 正解した問題数
+	AnsweredCount      int                 `json:"answeredCount" firestore:"answered_count"`                             // 回答した問題数
+	UnansweredCount    int                 `json:"unansweredCount" firestore:"unanswered_count"`                         // 未回答のまま完了した問題数 (不正解として扱う)
+	Points             float64             `json:"points" firestore:"points"`                                            // 採点方式に基づく得点
+	ScoringStrategy    ScoringStrategy     `json:"scoringStrategy" firestore:"scoring_strategy"`                         // 受験開始時に確定した採点方式
+	Percentage         float64             `json:"percentage" firestore:"percentage"`                                    // 得点率 (0-100)
+	ScaledScore        float64             `json:"scaledScore" firestore:"scaled_score"`                                 // 分野ごとの配点比率で重み付けした得点率 (0-100)
+	PassingScore       int                 `json:"passingScore" firestore:"passing_score"`                               // 受験開始時に確定した合格ライン(%)
+	DomainWeights      map[string]float64  `json:"domainWeights,omitempty" firestore:"domain_weights,omitempty"`         // 受験開始時に確定した分野ごとの配点比率
+	Passed             bool                `json:"passed" firestore:"passed"`                                            // 合否 (完了後のみ有効)
+	DomainScores       []DomainScore       `json:"domainScores,omitempty" firestore:"domain_scores,omitempty"`           // この受験の分野ごとの成績 (分野名順)。完了後のみ有効
+	QuestionIDs        []string            `json:"questionIds,omitempty" firestore:"question_ids,omitempty"`             // 受験対象の問題ID (出題順)。受験開始時の試験セットの内容を記録する
+	QuestionRevisions  map[string]int      `json:"questionRevisions,omitempty" firestore:"question_revisions,omitempty"` // Key: QuestionID, Value: 出題した問題の版番号
+	TotalQuestions     int                 `json:"totalQuestions" firestore:"total_questions"`
+	CurrentIndex       int                 `json:"currentIndex" firestore:"current_index"`
+	Answers            map[string][]string `json:"answers" firestore:"answers"`                                   // Key: QuestionID, Value: Selected Option IDs
+	FlaggedQuestionIDs []string            `json:"flaggedQuestionIds" firestore:"flagged_question_ids,omitempty"` // 見直しフラグを付けた問題ID (ID順)
+	FlagSummary        *FlagSummary        `json:"flagSummary,omitempty" firestore:"flag_summary,omitempty"`      // フラグの有無による成績比較。完了後のみ有効
+	TimeSpent          map[string]int      `json:"timeSpent,omitempty" firestore:"time_spent,omitempty"`          // Key: QuestionID, Value: 累計回答時間(秒)
+	StartedAt          time.Time           `json:"startedAt" firestore:"started_at"`
+	UpdatedAt          time.Time           `json:"updatedAt" firestore:"updated_at"`
+	Deadline           *time.Time          `json:"deadline,omitempty" firestore:"deadline,omitempty"`  // 制限時間付きの場合の終了期限。中断中の時間分は延長される
+	ActiveSeconds      int                 `json:"activeSeconds" firestore:"active_seconds"`           // 中断中を除いた受験時間の累計 (直近の再開以降の分は含まない)
+	LastResumedAt      time.Time           `json:"lastResumedAt" firestore:"last_resumed_at"`          // 直近で受験を開始・再開した日時
+	PausedAt           *time.Time          `json:"pausedAt,omitempty" firestore:"paused_at,omitempty"` // 中断した日時
+	CompletedAt        *time.Time          `firestore:"completed_at,omitempty"`
 }
 
 // NewAttempt は新しいAttemptドメインオブジェクトを生成します。
-func NewAttempt(id, userID, examID, examSetID string, totalQuestions int, now time.Time) (*Attempt, error) {
+func NewAttempt(id, userID, examID, examSetID string, mode AttemptMode, totalQuestions int, now time.Time) (*Attempt, error) {
 	if id == "" || userID == "" || examID == "" || examSetID == "" {
 		return nil, errors.New("AttemptのID, UserID, ExamID, ExamSetIDは必須です")
 	}
+	if !lo.Contains(AttemptModeValues(), mode) {
+		return nil, errors.Wrapf(ErrInvalidArgument, "不正な受験モードです: %s", mode)
+	}
 
 	return &Attempt{
 		ID:             id,
 		UserID:         userID,
 		ExamID:         examID,
 		ExamSetID:      examSetID,
+		Mode:           mode,
 		Status:         StatusInProgress,
 		Score:          0,
 		TotalQuestions: totalQuestions,
@@ -40,9 +66,87 @@ func NewAttempt(id, userID, examID, examSetID string, totalQuestions int, now ti
 		Answers:        make(map[string][]string),
 		StartedAt:      now,
 		UpdatedAt:      now,
+		LastResumedAt:  now,
 	}, nil
 }
 
+// SetTimeLimit は開始日時から limit 後を終了期限に設定します。
+// 練習形式の受験や limit が0以下の場合は制限時間を設けません。
+func (a *Attempt) SetTimeLimit(limit time.Duration) {
+	if a.Mode == ModePractice || limit <= 0 {
+		a.Deadline = nil
+		return
+	}
+	deadline := a.StartedAt.Add(limit)
+	a.Deadline = &deadline
+}
+
+// IsExpired は進行中の受験が終了期限を過ぎているかどうかを返します。
+// 中断中は時間が進まないため期限切れになりません。
+func (a *Attempt) IsExpired(now time.Time) bool {
+	return a.Deadline != nil && a.Status == StatusInProgress && !now.Before(*a.Deadline)
+}
+
+// RemainingTime は終了期限までの残り時間を返します。制限時間がない場合、または終了済みの場合は false を返します。
+// 中断中は中断した時点の残り時間を返します。
+func (a *Attempt) RemainingTime(now time.Time) (time.Duration, bool) {
+	if a.Deadline == nil || a.IsFinished() {
+		return 0, false
+	}
+	if a.Status == StatusPaused && a.PausedAt != nil {
+		now = *a.PausedAt
+	}
+	return max(a.Deadline.Sub(now), 0), true
+}
+
+// ElapsedActiveTime は中断中の時間を除いた受験時間を返します。
+func (a *Attempt) ElapsedActiveTime(now time.Time) time.Duration {
+	elapsed := time.Duration(a.ActiveSeconds) * time.Second
+	if a.Status == StatusInProgress {
+		elapsed += max(now.Sub(a.activeSince()), 0)
+	}
+	return elapsed
+}
+
+// activeSince は現在の受験区間の開始日時を返します。
+// 中断・再開の導入前に作成された受験は LastResumedAt を持たないため、開始日時を使用します。
+func (a *Attempt) activeSince() time.Time {
+	if a.LastResumedAt.IsZero() {
+		return a.StartedAt
+	}
+	return a.LastResumedAt
+}
+
+// RecordTimeSpent は問題ごとの累計回答時間(秒)を記録します。
+// クライアントからは累計値が送信されるため、通信の前後で古い値が届いても減らないよう大きい方を採用します。
+func (a *Attempt) RecordTimeSpent(timeSpent map[string]int) {
+	if len(timeSpent) == 0 {
+		return
+	}
+	if a.TimeSpent == nil {
+		a.TimeSpent = make(map[string]int)
+	}
+	for qID, seconds := range timeSpent {
+		a.TimeSpent[qID] = max(a.TimeSpent[qID], seconds)
+	}
+}
+
+// CanRevealAnswers は正解と解説を閲覧可能な状態かどうかを返します。
+// 受験完了後、または練習モードの場合のみ閲覧できます。
+func (a *Attempt) CanRevealAnswers() bool {
+	return a.Status == StatusCompleted || a.Mode == ModePractice
+}
+
+// AttemptMode は受験の形式を定義します。
+//
+// tygo:enum
+type AttemptMode string
+
+const (
+	ModeExam     AttemptMode = "exam"     // 本番形式 (完了まで正解・解説は非表示)
+	ModePractice AttemptMode = "practice" // 練習形式 (受験中も正解・解説を閲覧可能)
+)
+
 // AttemptStatus は受験の進捗状態を定義します。
 //
 // tygo:enum
@@ -52,5 +156,81 @@ const (
 	StatusInProgress AttemptStatus = "in_progress" // 進行中
 	StatusPaused     AttemptStatus = "paused"      // 中断中
 	StatusCompleted  AttemptStatus = "completed"   // 完了
+	StatusAbandoned  AttemptStatus = "abandoned"   // 放棄
 )
 
+// UnfinishedAttemptStatuses は再開可能な(未完了の)受験状態の一覧です。
+var UnfinishedAttemptStatuses = []AttemptStatus{StatusInProgress, StatusPaused}
+
+// attemptTransitions は受験状態ごとの遷移可能な状態です。
+// completed と abandoned は終端状態のため遷移先を持ちません。
+var attemptTransitions = map[AttemptStatus][]AttemptStatus{
+	StatusInProgress: {StatusPaused, StatusCompleted, StatusAbandoned},
+	StatusPaused:     {StatusInProgress, StatusCompleted, StatusAbandoned},
+}
+
+// CanTransitionTo は現在の状態から next へ遷移可能かどうかを返します。
+func (s AttemptStatus) CanTransitionTo(next AttemptStatus) bool {
+	return lo.Contains(attemptTransitions[s], next)
+}
+
+// IsFinished は受験が完了または放棄されており、これ以上更新できないかどうかを返します。
+func (a *Attempt) IsFinished() bool {
+	return a.Status == StatusCompleted || a.Status == StatusAbandoned
+}
+
+// Pause は進行中の受験を中断します。それまでの受験時間を累計に加算します。
+func (a *Attempt) Pause(now time.Time) error {
+	if err := a.transitionTo(StatusPaused, now); err != nil {
+		return err
+	}
+	a.PausedAt = &now
+	return nil
+}
+
+// Resume は中断中の受験を再開します。制限時間付きの場合は、中断していた時間分だけ終了期限を延長します。
+func (a *Attempt) Resume(now time.Time) error {
+	pausedAt := a.PausedAt
+	if err := a.transitionTo(StatusInProgress, now); err != nil {
+		return err
+	}
+	if a.Deadline != nil && pausedAt != nil {
+		deadline := a.Deadline.Add(max(now.Sub(*pausedAt), 0))
+		a.Deadline = &deadline
+	}
+	a.PausedAt = nil
+	a.LastResumedAt = now
+	return nil
+}
+
+// Complete は受験を完了状態にします。採点結果の設定は呼び出し側で行います。
+func (a *Attempt) Complete(now time.Time) error {
+	if err := a.transitionTo(StatusCompleted, now); err != nil {
+		return err
+	}
+	a.PausedAt = nil
+	a.CompletedAt = &now
+	return nil
+}
+
+// Abandon は未完了の受験を放棄済みにします。
+func (a *Attempt) Abandon(now time.Time) error {
+	if err := a.transitionTo(StatusAbandoned, now); err != nil {
+		return err
+	}
+	a.PausedAt = nil
+	return nil
+}
+
+// transitionTo は状態遷移の妥当性を検証し、進行中の区間の受験時間を累計に加算してから状態を更新します。
+func (a *Attempt) transitionTo(next AttemptStatus, now time.Time) error {
+	if !a.Status.CanTransitionTo(next) {
+		return errors.Wrapf(ErrFailedPrecondition, "受験の状態を %s から %s に変更できません", a.Status, next)
+	}
+	if a.Status == StatusInProgress {
+		a.ActiveSeconds += int(max(now.Sub(a.activeSince()), 0).Seconds())
+	}
+	a.Status = next
+	a.UpdatedAt = now
+	return nil
+}
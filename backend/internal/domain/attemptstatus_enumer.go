@@ -6,6 +6,7 @@ func (AttemptStatus) Values() []string {
 		"in_progress",
 		"paused",
 		"completed",
+		"abandoned",
 	}
 }
 
@@ -14,5 +15,6 @@ func AttemptStatusValues() []AttemptStatus {
 		"in_progress",
 		"paused",
 		"completed",
+		"abandoned",
 	}
 }
@@ -1,13 +1,107 @@
 package domain
 
-import "time"
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
 
 // Exam は認定試験を表します（例: "Google Cloud Certified - Professional Cloud Developer"）。
 type Exam struct {
-	ID          string    `json:"id" firestore:"id"`                   // 例: "professional_cloud_developer"
-	Code        string    `json:"code" firestore:"code"`               // 例: "PCD"
-	Name        string    `json:"name" firestore:"name"`               // 例: "Professional Cloud Developer"
-	Description string    `json:"description" firestore:"description"` // 例: "あなたの能力を評価します..."
-	ImageURL    string    `json:"imageUrl" firestore:"image_url"`      // 試験のロゴ/アイコンのURL
-	CreatedAt   time.Time `json:"createdAt" firestore:"created_at"`
+	ID              string             `json:"id" firestore:"id"`                                                // 例: "professional_cloud_developer"
+	Code            string             `json:"code" firestore:"code"`                                            // 例: "PCD"
+	Name            string             `json:"name" firestore:"name"`                                            // 例: "Professional Cloud Developer"
+	Description     string             `json:"description" firestore:"description"`                              // 例: "あなたの能力を評価します..."
+	ImageURL        string             `json:"imageUrl" firestore:"image_url"`                                   // 試験のロゴ/アイコンのURL
+	DurationMinutes int                `json:"durationMinutes" firestore:"duration_minutes"`                     // 本番形式の制限時間(分)。0の場合は無制限
+	ScoringStrategy ScoringStrategy    `json:"scoringStrategy,omitempty" firestore:"scoring_strategy,omitempty"` // 採点方式。未指定の場合は all_or_nothing
+	PassingScore    int                `json:"passingScore" firestore:"passing_score"`                           // 合格ライン(%)。0の場合は DefaultPassingScore
+	DomainWeights   map[string]float64 `json:"domainWeights,omitempty" firestore:"domain_weights,omitempty"`     // 分野ごとの配点比率。未指定の場合や、出題された分野に指定がない場合は全問均等
+	DisplayOrder    int                `json:"displayOrder" firestore:"display_order"`                           // 一覧での表示順 (昇順)
+	RetiredAt       *time.Time         `json:"retiredAt,omitempty" firestore:"retired_at,omitempty"`             // 提供を終了した日時。終了した試験は一覧に表示せず、新たに受験できない
+	CreatedAt       time.Time          `json:"createdAt" firestore:"created_at"`
+	UpdatedAt       time.Time          `json:"updatedAt" firestore:"updated_at"`
+}
+
+var (
+	// examIDPattern は試験IDの形式です。FirestoreのドキュメントIDとURLに使うため、英小文字・数字・ハイフン・アンダースコアに限ります。
+	examIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+	// examCodePattern は試験コードの形式です。問題ID (例: "PCD_SET1_001") の接頭辞に使うため、英大文字と数字に限ります。
+	examCodePattern = regexp.MustCompile(`^[A-Z][A-Z0-9]*$`)
+)
+
+// NewExam は試験を作成します。
+func NewExam(id, code, name, description, imageURL string, durationMinutes, passingScore int, now time.Time) (*Exam, error) {
+	if !examIDPattern.MatchString(id) {
+		return nil, errors.Wrapf(ErrInvalidArgument, "試験IDは英小文字・数字・ハイフン・アンダースコアで指定してください: %q", id)
+	}
+
+	return &Exam{
+		ID:              id,
+		Code:            code,
+		Name:            name,
+		Description:     description,
+		ImageURL:        imageURL,
+		DurationMinutes: durationMinutes,
+		PassingScore:    passingScore,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// Validate は試験の設定が正しいかを検証します。
+// 試験コードの重複は他の試験と比較する必要があるため、ここでは検証しません。
+func (e *Exam) Validate() error {
+	verr := &ValidationError{}
+	if !examCodePattern.MatchString(e.Code) {
+		verr.Add("code", "試験コードは英大文字で始まる英大文字と数字で指定してください (例: PCD)")
+	}
+	if e.Name == "" {
+		verr.Add("name", "試験名は必須です")
+	}
+	if e.DurationMinutes < 0 {
+		verr.Add("durationMinutes", "制限時間は0以上で指定してください")
+	}
+	if e.ScoringStrategy != "" && !slices.Contains(ScoringStrategyValues(), e.ScoringStrategy) {
+		verr.Add("scoringStrategy", fmt.Sprintf("不正な採点方式です: %s", e.ScoringStrategy))
+	}
+	if e.PassingScore < 0 || e.PassingScore > 100 {
+		verr.Add("passingScore", "合格ラインは0から100の範囲で指定してください")
+	}
+	for _, name := range slices.Sorted(maps.Keys(e.DomainWeights)) {
+		if e.DomainWeights[name] <= 0 {
+			verr.Add("domainWeights."+name, "配点比率は0より大きい値で指定してください")
+		}
+	}
+	return verr.ErrOrNil()
+}
+
+// IsRetired は試験の提供を終了したかどうかを返します。
+func (e *Exam) IsRetired() bool {
+	return e.RetiredAt != nil
+}
+
+// Retire は試験の提供を終了します。既に終了している場合は終了日時を変更しません。
+// 完了済みの受験や成績はそのまま残り、履歴として参照できます。
+func (e *Exam) Retire(now time.Time) {
+	if e.IsRetired() {
+		return
+	}
+	e.RetiredAt = &now
+	e.UpdatedAt = now
+}
+
+// SortExams は試験を表示順に並べ替えます。表示順が同じ試験はID順に並べます。
+func SortExams(exams []Exam) {
+	slices.SortFunc(exams, func(a, b Exam) int {
+		if c := cmp.Compare(a.DisplayOrder, b.DisplayOrder); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
 }
@@ -1,14 +1,173 @@
 package domain
 
-import "time"
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
 
 // ExamSet は模擬試験のセットを表します（例: "Practice Exam 1"）。
 // Firestore Path: exams/{examID}/sets/{id}
 type ExamSet struct {
-	ID          string    `json:"id" firestore:"id"`                   // 例: "practice_exam_1"
-	ExamID      string    `json:"examId" firestore:"exam_id"`          // 親のExam ID
-	Name        string    `json:"name" firestore:"name"`               // 例: "Practice Exam 1"
-	Description string    `json:"description" firestore:"description"` // 例: "50 questions covering all domains"
-	QuestionIDs []string  `json:"questionIds" firestore:"question_ids"` // 含まれる問題IDのリスト (冗長化)
-	CreatedAt   time.Time `json:"createdAt" firestore:"created_at"`
+	ID              string          `json:"id" firestore:"id"`                                                // 例: "practice_exam_1"
+	ExamID          string          `json:"examId" firestore:"exam_id"`                                       // 親のExam ID
+	Name            string          `json:"name" firestore:"name"`                                            // 例: "Practice Exam 1"
+	Description     string          `json:"description" firestore:"description"`                              // 例: "50 questions covering all domains"
+	QuestionIDs     []string        `json:"questionIds" firestore:"question_ids"`                             // 含まれる問題IDのリスト (出題順、冗長化)。問題のアップロード・削除・セットの編集時に更新する
+	DurationMinutes int             `json:"durationMinutes,omitempty" firestore:"duration_minutes,omitempty"` // 制限時間(分)。0の場合は試験の設定を使用
+	ScoringStrategy ScoringStrategy `json:"scoringStrategy,omitempty" firestore:"scoring_strategy,omitempty"` // 採点方式。未指定の場合は試験の設定を使用
+	PassingScore    int             `json:"passingScore,omitempty" firestore:"passing_score,omitempty"`       // 合格ライン(%)。0の場合は試験の設定を使用
+	CreatedAt       time.Time       `json:"createdAt" firestore:"created_at"`
+}
+
+// TimeLimit は本番形式で受験する際の制限時間を返します。
+// セットに指定がない場合は試験の設定を使用し、どちらも0の場合は無制限(0)です。
+func (s *ExamSet) TimeLimit(exam *Exam) time.Duration {
+	minutes := s.DurationMinutes
+	if minutes == 0 && exam != nil {
+		minutes = exam.DurationMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// ResolveScoringStrategy はこのセットで使用する採点方式を返します。
+// セット、試験の順に設定を参照し、どちらも未指定の場合は DefaultScoringStrategy を返します。
+func (s *ExamSet) ResolveScoringStrategy(exam *Exam) ScoringStrategy {
+	if s.ScoringStrategy != "" {
+		return s.ScoringStrategy
+	}
+	if exam != nil && exam.ScoringStrategy != "" {
+		return exam.ScoringStrategy
+	}
+	return DefaultScoringStrategy
+}
+
+// ResolvePassingScore はこのセットの合格ライン(%)を返します。
+// セット、試験の順に設定を参照し、どちらも未指定の場合は DefaultPassingScore を返します。
+func (s *ExamSet) ResolvePassingScore(exam *Exam) int {
+	if s.PassingScore > 0 {
+		return s.PassingScore
+	}
+	if exam != nil && exam.PassingScore > 0 {
+		return exam.PassingScore
+	}
+	return DefaultPassingScore
+}
+
+// NewExamSet は問題を含まない試験セットを作成します。
+// 個別に生成するセットの ID (AdaptiveExamSetID, MistakesExamSetID) は使用できません。
+func NewExamSet(id, examID, name, description string, now time.Time) (*ExamSet, error) {
+	if id == "" || examID == "" {
+		return nil, errors.Wrap(ErrInvalidArgument, "試験セットのIDとExamIDは必須です")
+	}
+	if id == AdaptiveExamSetID || id == MistakesExamSetID {
+		return nil, errors.Wrapf(ErrInvalidArgument, "試験セットのIDに %s は使用できません", id)
+	}
+
+	return &ExamSet{
+		ID:          id,
+		ExamID:      examID,
+		Name:        name,
+		Description: description,
+		QuestionIDs: []string{},
+		CreatedAt:   now,
+	}, nil
+}
+
+// NewGeneratedExamSet は受験ごとに個別に生成するセット (AdaptiveExamSetID, MistakesExamSetID) を作成します。
+// 生成したセットは保存せず、採点方式・合格ラインは試験の設定から引き継ぎます。
+func NewGeneratedExamSet(id, examID string) (*ExamSet, error) {
+	if id != AdaptiveExamSetID && id != MistakesExamSetID {
+		return nil, errors.Wrapf(ErrInvalidArgument, "個別に生成するセットではありません: %s", id)
+	}
+	if examID == "" {
+		return nil, errors.Wrap(ErrInvalidArgument, "ExamIDは必須です")
+	}
+
+	return &ExamSet{ID: id, ExamID: examID}, nil
+}
+
+// Validate は試験セットの設定が正しいかを検証します。
+func (s *ExamSet) Validate() error {
+	verr := &ValidationError{}
+	if s.Name == "" {
+		verr.Add("name", "試験セット名は必須です")
+	}
+	if s.DurationMinutes < 0 {
+		verr.Add("durationMinutes", "制限時間は0以上で指定してください")
+	}
+	if s.ScoringStrategy != "" && !slices.Contains(ScoringStrategyValues(), s.ScoringStrategy) {
+		verr.Add("scoringStrategy", fmt.Sprintf("不正な採点方式です: %s", s.ScoringStrategy))
+	}
+	if s.PassingScore < 0 || s.PassingScore > 100 {
+		verr.Add("passingScore", "合格ラインは0から100の範囲で指定してください")
+	}
+	return verr.ErrOrNil()
+}
+
+// AddQuestionIDs は問題をセットの末尾に追加します。既に含まれる問題は追加しません。
+func (s *ExamSet) AddQuestionIDs(ids ...string) {
+	for _, id := range ids {
+		if !slices.Contains(s.QuestionIDs, id) {
+			s.QuestionIDs = append(s.QuestionIDs, id)
+		}
+	}
+}
+
+// RemoveQuestionIDs は問題をセットから取り除きます。残りの問題の順序は維持します。
+func (s *ExamSet) RemoveQuestionIDs(ids ...string) {
+	s.QuestionIDs = slices.DeleteFunc(s.QuestionIDs, func(id string) bool {
+		return slices.Contains(ids, id)
+	})
+}
+
+// ReorderQuestions はセットの問題の出題順を並べ替えます。
+// ids はセットに含まれる問題をちょうど1回ずつ含む必要があります。
+func (s *ExamSet) ReorderQuestions(ids []string) error {
+	verr := &ValidationError{}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		switch {
+		case seen[id]:
+			verr.Add("questionIds", fmt.Sprintf("問題が重複しています: %s", id))
+		case !slices.Contains(s.QuestionIDs, id):
+			verr.Add("questionIds", fmt.Sprintf("試験セットに含まれない問題です: %s", id))
+		}
+		seen[id] = true
+	}
+	for _, id := range s.QuestionIDs {
+		if !seen[id] {
+			verr.Add("questionIds", fmt.Sprintf("並び順に含まれていない問題があります: %s", id))
+		}
+	}
+	if err := verr.ErrOrNil(); err != nil {
+		return err
+	}
+
+	s.QuestionIDs = slices.Clone(ids)
+	return nil
+}
+
+// SortQuestions は問題をセットの出題順に並べ替えて返します。
+// QuestionIDs に含まれない問題は、元の順序のまま末尾に並べます。
+func (s *ExamSet) SortQuestions(questions []Question) []Question {
+	position := make(map[string]int, len(s.QuestionIDs))
+	for i, id := range s.QuestionIDs {
+		position[id] = i
+	}
+	rank := func(q Question) int {
+		if i, ok := position[q.ID]; ok {
+			return i
+		}
+		return len(s.QuestionIDs)
+	}
+
+	sorted := slices.Clone(questions)
+	slices.SortStableFunc(sorted, func(a, b Question) int {
+		return cmp.Compare(rank(a), rank(b))
+	})
+	return sorted
 }
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"math"
+	"slices"
+
+	"github.com/samber/lo"
+)
+
+// FlagSummary は見直しフラグを付けた問題と付けなかった問題の成績の比較です。
+type FlagSummary struct {
+	Flagged   FlagGroupScore `json:"flagged" firestore:"flagged"`
+	Unflagged FlagGroupScore `json:"unflagged" firestore:"unflagged"`
+}
+
+// FlagGroupScore はフラグの有無で分けた問題群の成績です。
+type FlagGroupScore struct {
+	CorrectCount int `json:"correctCount" firestore:"correct_count"`
+	TotalCount   int `json:"totalCount" firestore:"total_count"`
+	AccuracyRate int `json:"accuracyRate" firestore:"accuracy_rate"` // パーセンテージ (0-100)
+}
+
+// Add は1問分の採点結果を加算します。
+func (s *FlagSummary) Add(flagged, correct bool) {
+	group := &s.Unflagged
+	if flagged {
+		group = &s.Flagged
+	}
+	group.TotalCount++
+	if correct {
+		group.CorrectCount++
+	}
+	group.AccuracyRate = int(math.Round(float64(group.CorrectCount) / float64(group.TotalCount) * 100))
+}
+
+// SetFlaggedQuestions は見直しフラグを付けた問題を ids で置き換えます。重複は除き、ID順に保持します。
+func (a *Attempt) SetFlaggedQuestions(ids []string) {
+	flagged := lo.Uniq(ids)
+	slices.Sort(flagged)
+	a.FlaggedQuestionIDs = flagged
+}
+
+// IsFlagged は問題に見直しフラグが付いているかどうかを返します。
+func (a *Attempt) IsFlagged(questionID string) bool {
+	return lo.Contains(a.FlaggedQuestionIDs, questionID)
+}
+
+// ValidateFlaggedQuestionIDs は見直しフラグを付ける問題が試験セットに含まれるかを検証します。
+func ValidateFlaggedQuestionIDs(questions []Question, ids []string) error {
+	verr := &ValidationError{}
+	for _, id := range lo.Uniq(ids) {
+		if !lo.ContainsBy(questions, func(q Question) bool { return q.ID == id }) {
+			verr.Add("flaggedQuestionIds."+id, "この試験セットに存在しない問題です")
+		}
+	}
+	return verr.ErrOrNil()
+}
@@ -0,0 +1,22 @@
+// Code generated by go run scripts/gen_enum_methods.go; DO NOT EDIT.
+package domain
+
+func (ItemFlag) Values() []string {
+	return []string{
+		"too_easy",
+		"too_hard",
+		"low_discrimination",
+		"negative_discrimination",
+		"distractor_over_key",
+	}
+}
+
+func ItemFlagValues() []ItemFlag {
+	return []ItemFlag{
+		"too_easy",
+		"too_hard",
+		"low_discrimination",
+		"negative_discrimination",
+		"distractor_over_key",
+	}
+}
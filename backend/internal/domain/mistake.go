@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+const (
+	// MistakesExamSetID は間違えた問題だけで構成する個別の問題セットを表す ExamSetID です。
+	MistakesExamSetID = "mistakes"
+
+	// MistakeClearStreak は間違えた問題を連続で何回正解したら間違いノートから外すかを表します。
+	MistakeClearStreak = 2
+)
+
+// Mistake は間違いノートの1問分で、完了済みの受験の回答から導出します。
+// 未回答の問題は回答していないため、間違い・正解のどちらにも数えません。
+type Mistake struct {
+	QuestionID    string    `json:"questionId" firestore:"question_id"`
+	Domain        string    `json:"domain" firestore:"domain"`
+	WrongCount    int       `json:"wrongCount" firestore:"wrong_count"`       // これまでに間違えた回数
+	CorrectStreak int       `json:"correctStreak" firestore:"correct_streak"` // 直近で連続して正解した回数
+	LastWrongAt   time.Time `json:"lastWrongAt" firestore:"last_wrong_at"`    // 最後に間違えた受験の完了日時
+	LastSeenAt    time.Time `json:"lastSeenAt" firestore:"last_seen_at"`      // 最後に回答した受験の完了日時
+}
+
+// IsCleared は連続で MistakeClearStreak 回以上正解し、間違いノートから外れたかどうかを返します。
+func (m *Mistake) IsCleared() bool {
+	return m.CorrectStreak >= MistakeClearStreak
+}
+
+// BuildMistakes は完了済みの受験の回答から、一度でも間違えた問題の一覧を導出します。
+// attempts は受験の完了日時順に評価します。
+// questionFor は受験と問題IDから、その受験で出題した版の問題を返します。見つからない(削除された)問題は無視します。
+// isCorrect は問題と回答を受け取り、正解かどうかを返します。
+// 結果は最後に間違えた日時の新しい順で、連続正解により外れた問題も含みます。
+func BuildMistakes(attempts []Attempt, questionFor func(a Attempt, questionID string) (Question, bool), isCorrect func(q Question, selected []string) bool) []Mistake {
+	completed := make([]Attempt, 0, len(attempts))
+	for _, a := range attempts {
+		if a.Status == StatusCompleted && a.CompletedAt != nil {
+			completed = append(completed, a)
+		}
+	}
+	slices.SortFunc(completed, func(a, b Attempt) int {
+		return a.CompletedAt.Compare(*b.CompletedAt)
+	})
+
+	byID := make(map[string]*Mistake)
+	for _, a := range completed {
+		for qID, selected := range a.Answers {
+			q, ok := questionFor(a, qID)
+			if !ok || len(selected) == 0 {
+				continue
+			}
+			correct := isCorrect(q, selected)
+			m, ok := byID[qID]
+			if !ok {
+				if correct {
+					continue
+				}
+				m = &Mistake{QuestionID: qID, Domain: q.Domain}
+				byID[qID] = m
+			}
+			if correct {
+				m.CorrectStreak++
+			} else {
+				m.WrongCount++
+				m.CorrectStreak = 0
+				m.LastWrongAt = *a.CompletedAt
+			}
+			m.LastSeenAt = *a.CompletedAt
+		}
+	}
+
+	mistakes := make([]Mistake, 0, len(byID))
+	for _, m := range byID {
+		mistakes = append(mistakes, *m)
+	}
+	slices.SortFunc(mistakes, func(a, b Mistake) int {
+		if c := b.LastWrongAt.Compare(a.LastWrongAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.QuestionID, b.QuestionID)
+	})
+	return mistakes
+}
@@ -1,9 +1,17 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+)
+
+// QuestionType の値です。
+const (
+	QuestionTypeMultipleChoice = "multiple-choice" // 単一選択
+	QuestionTypeMultiSelect    = "multi-select"    // 複数選択
 )
 
 // Question は1つの問題を表すマスターデータです。
@@ -21,6 +29,9 @@ type Question struct {
 	ImageURL           string         `json:"imageUrl,omitempty" firestore:"image_url,omitempty"`  // 解説図などのURL
 	ReferenceURLs      []string       `json:"referenceUrls,omitempty" firestore:"reference_urls"`  // 参考リンク
 	CreatedAt          time.Time      `json:"createdAt" firestore:"created_at"`
+	Revision           int            `json:"revision" firestore:"revision"`                       // 版番号。編集するたびに増える (0は版管理の導入前に作成された問題で、版1として扱う)
+	UpdatedAt          time.Time      `json:"updatedAt" firestore:"updated_at"`                    // 管理画面で最後に編集した日時
+	UpdatedBy          string         `json:"updatedBy,omitempty" firestore:"updated_by,omitempty"` // 最後に編集した管理者のユーザーID
 }
 
 // AnswerOption は問題の個々の選択肢です。
@@ -57,3 +68,49 @@ func NewQuestion(
 		CreatedAt:          now,
 	}, nil
 }
+
+// Validate は問題の内容が整合しているかを検証します。
+// 選択肢のIDが一意であること、正解がすべて選択肢に含まれること、単一選択の問題の正解が1つであることを確認します。
+func (q *Question) Validate() error {
+	verr := &ValidationError{}
+	if q.QuestionText == "" {
+		verr.Add("question", "問題文は必須です")
+	}
+	if q.QuestionType != QuestionTypeMultipleChoice && q.QuestionType != QuestionTypeMultiSelect {
+		verr.Add("questionType", fmt.Sprintf("不正な問題形式です: %s", q.QuestionType))
+	}
+
+	if len(q.Options) < 2 {
+		verr.Add("answerOptions", "選択肢は2つ以上必要です")
+	}
+	optionIDs := make(map[string]bool, len(q.Options))
+	for i, o := range q.Options {
+		field := fmt.Sprintf("answerOptions[%d]", i)
+		switch {
+		case o.ID == "":
+			verr.Add(field+".id", "選択肢のIDは必須です")
+		case optionIDs[o.ID]:
+			verr.Add(field+".id", fmt.Sprintf("選択肢のIDが重複しています: %s", o.ID))
+		}
+		if o.Text == "" {
+			verr.Add(field+".answer", "選択肢の文言は必須です")
+		}
+		optionIDs[o.ID] = true
+	}
+
+	if len(q.CorrectAnswers) == 0 {
+		verr.Add("correctAnswers", "正解は1つ以上必要です")
+	}
+	if q.QuestionType == QuestionTypeMultipleChoice && len(q.CorrectAnswers) > 1 {
+		verr.Add("correctAnswers", "単一選択の問題の正解は1つです")
+	}
+	if dup := lo.FindDuplicates(q.CorrectAnswers); len(dup) > 0 {
+		verr.Add("correctAnswers", fmt.Sprintf("正解が重複しています: %v", dup))
+	}
+	for _, id := range q.CorrectAnswers {
+		if !optionIDs[id] {
+			verr.Add("correctAnswers", fmt.Sprintf("存在しない選択肢です: %s", id))
+		}
+	}
+	return verr.ErrOrNil()
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// QuestionRevision は問題の特定の版の内容です。
+// 問題を編集するたびに新しい版が作成され、受験は出題した版で振り返り・再採点されます。
+// Firestore Path: questions/{questionID}/revisions/{revision}
+type QuestionRevision struct {
+	QuestionID string    `json:"questionId" firestore:"question_id"`
+	Revision   int       `json:"revision" firestore:"revision"`
+	Question   Question  `json:"question" firestore:"question"`
+	CreatedAt  time.Time `json:"createdAt" firestore:"created_at"`
+	CreatedBy  string    `json:"createdBy,omitempty" firestore:"created_by,omitempty"` // 版を作成した管理者のユーザーID。初版の場合は空
+}
+
+// CurrentRevision は問題の現在の版番号を返します。
+// 版管理の導入前に作成された問題は Revision が0のため、版1として扱います。
+func (q *Question) CurrentRevision() int {
+	return max(q.Revision, 1)
+}
+
+// NewQuestionRevision は問題の現在の内容を版として記録します。
+func NewQuestionRevision(q Question) QuestionRevision {
+	createdAt := q.UpdatedAt
+	if createdAt.IsZero() {
+		createdAt = q.CreatedAt
+	}
+	return QuestionRevision{
+		QuestionID: q.ID,
+		Revision:   q.CurrentRevision(),
+		Question:   q,
+		CreatedAt:  createdAt,
+		CreatedBy:  q.UpdatedBy,
+	}
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/samber/lo"
+
+	"nearline/backend/internal/util"
+)
+
+// QuestionStats は全ユーザーの回答を問題ごとに集計した項目分析用の統計です。
+// 本番形式で完了した受験のみを集計します (練習形式は受験中に正解を閲覧できるため除外)。
+// 問題の版ごとに集計し、編集前の版への回答が編集後の問題の分析に混ざらないようにします。
+// Firestore Path: question_stats/{questionID} (版1) または question_stats/{questionID}@{revision} (版2以降)
+//
+// 識別力(点双列相関)は集計値から算出できるよう、受験ごとの「残りの問題の得点率」の和・二乗和を保持します。
+type QuestionStats struct {
+	QuestionID      string         `json:"questionId" firestore:"question_id"`
+	ExamID          string         `json:"examId" firestore:"exam_id"`
+	ExamSetID       string         `json:"examSetId" firestore:"exam_set_id"`
+	Revision        int            `json:"revision" firestore:"revision"`                // 集計対象の問題の版 (0は版ごとの集計の導入前の統計で、版1として扱う)
+	ResponseCount   int            `json:"responseCount" firestore:"response_count"`     // 出題数 (未回答を含む)
+	CorrectCount    int            `json:"correctCount" firestore:"correct_count"`       // 正解数
+	UnansweredCount int            `json:"unansweredCount" firestore:"unanswered_count"` // 未回答数
+	OptionCounts    map[string]int `json:"optionCounts" firestore:"option_counts"`       // Key: Option ID, Value: 選択された回数
+	SumRest         float64        `json:"-" firestore:"sum_rest"`                       // 残りの問題の得点率(0-1)の和
+	SumRestSq       float64        `json:"-" firestore:"sum_rest_sq"`                    // 残りの問題の得点率の二乗和
+	SumRestCorrect  float64        `json:"-" firestore:"sum_rest_correct"`               // 正解者の残りの問題の得点率の和
+	UpdatedAt       time.Time      `json:"updatedAt" firestore:"updated_at"`
+}
+
+// QuestionResponse は1回の受験における1問分の回答結果です。QuestionStats への加算単位になります。
+type QuestionResponse struct {
+	QuestionID      string
+	ExamID          string
+	ExamSetID       string
+	Revision        int // 出題した問題の版
+	SelectedOptions []string
+	Correct         bool
+	RestScore       float64 // この問題を除いた受験の得点率 (0-1)。修正済み項目-全体相関の算出に使用します
+}
+
+// AddResponse は回答結果を集計に加算します。
+func (s *QuestionStats) AddResponse(r QuestionResponse, now time.Time) {
+	if s.OptionCounts == nil {
+		s.OptionCounts = make(map[string]int)
+	}
+	s.QuestionID, s.ExamID, s.ExamSetID, s.Revision = r.QuestionID, r.ExamID, r.ExamSetID, r.Revision
+	s.ResponseCount++
+	if len(r.SelectedOptions) == 0 {
+		s.UnansweredCount++
+	}
+	for _, id := range r.SelectedOptions {
+		s.OptionCounts[id]++
+	}
+	s.SumRest += r.RestScore
+	s.SumRestSq += r.RestScore * r.RestScore
+	if r.Correct {
+		s.CorrectCount++
+		s.SumRestCorrect += r.RestScore
+	}
+	s.UpdatedAt = now
+}
+
+// QuestionRevision は集計対象の問題の版を返します。
+// 版ごとの集計の導入前の統計は Revision が0のため、版1として扱います。
+func (s *QuestionStats) QuestionRevision() int {
+	return max(s.Revision, 1)
+}
+
+// PValue は正答率 (0-1) を返します。値が高いほど易しい問題です。
+func (s *QuestionStats) PValue() float64 {
+	if s.ResponseCount == 0 {
+		return 0
+	}
+	return float64(s.CorrectCount) / float64(s.ResponseCount)
+}
+
+// PointBiserial は正誤と残りの問題の得点率との点双列相関係数 (識別力) を返します。
+// 全員が正解・不正解の場合や得点率にばらつきがない場合は算出できないため false を返します。
+func (s *QuestionStats) PointBiserial() (float64, bool) {
+	n := float64(s.ResponseCount)
+	n1 := float64(s.CorrectCount)
+	n0 := n - n1
+	if n1 == 0 || n0 == 0 {
+		return 0, false
+	}
+
+	mean := s.SumRest / n
+	variance := s.SumRestSq/n - mean*mean
+	if variance <= 0 {
+		return 0, false
+	}
+
+	mean1 := s.SumRestCorrect / n1
+	mean0 := (s.SumRest - s.SumRestCorrect) / n0
+	p := n1 / n
+	return (mean1 - mean0) / math.Sqrt(variance) * math.Sqrt(p*(1-p)), true
+}
+
+// ItemFlag は項目分析で検出された問題の懸念点です。
+//
+// tygo:enum
+type ItemFlag string
+
+const (
+	ItemFlagTooEasy                ItemFlag = "too_easy"                // 正答率が高すぎる
+	ItemFlagTooHard                ItemFlag = "too_hard"                // 正答率が低すぎる
+	ItemFlagLowDiscrimination      ItemFlag = "low_discrimination"      // 成績上位者と下位者の差がつかない
+	ItemFlagNegativeDiscrimination ItemFlag = "negative_discrimination" // 成績下位者の方が正解しやすい (正解の誤りの疑い)
+	ItemFlagDistractorOverKey      ItemFlag = "distractor_over_key"     // 誤答の選択肢が正解の選択肢より多く選ばれている
+)
+
+// 項目分析のしきい値です。
+const (
+	ItemTooEasyPValue       = 0.95
+	ItemTooHardPValue       = 0.25
+	ItemLowDiscrimination   = 0.2
+	DefaultItemMinResponses = 30 // 判定に必要な最小の回答数
+)
+
+// Analyze は問題の集計から懸念点を検出します。回答数が minResponses 未満の場合は判定しません。
+func (s *QuestionStats) Analyze(q Question, minResponses int) []ItemFlag {
+	flags := []ItemFlag{}
+	if s.ResponseCount < max(minResponses, 1) {
+		return flags
+	}
+
+	p := s.PValue()
+	if p >= ItemTooEasyPValue {
+		flags = append(flags, ItemFlagTooEasy)
+	}
+	if p <= ItemTooHardPValue {
+		flags = append(flags, ItemFlagTooHard)
+	}
+
+	if r, ok := s.PointBiserial(); ok {
+		switch {
+		case r < 0:
+			flags = append(flags, ItemFlagNegativeDiscrimination)
+		case r < ItemLowDiscrimination:
+			flags = append(flags, ItemFlagLowDiscrimination)
+		}
+	}
+
+	// 複数選択問題では、最も選ばれなかった正解の選択肢と比較します
+	if len(q.CorrectAnswers) > 0 {
+		keyed := lo.Min(util.Map(q.CorrectAnswers, func(id string) int {
+			return s.OptionCounts[id]
+		}))
+		if lo.SomeBy(q.Options, func(o AnswerOption) bool {
+			return !lo.Contains(q.CorrectAnswers, o.ID) && s.OptionCounts[o.ID] > keyed
+		}) {
+			flags = append(flags, ItemFlagDistractorOverKey)
+		}
+	}
+
+	return flags
+}
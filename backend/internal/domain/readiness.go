@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+const (
+	// ReadinessWindow は合格可能性の推定に使用する直近の受験の最大件数です。
+	ReadinessWindow = 10
+	// ReadinessDecay は1回前の受験ほど重みを何倍にするかを表す減衰率です。最新の受験の重みは1です。
+	ReadinessDecay = 0.8
+	// ReadinessMinAttempts は合格可能性を推定するのに必要な受験の最小件数です。
+	ReadinessMinAttempts = 3
+
+	// readinessMinStdDev は得点のばらつきの下限(ポイント)です。
+	// 同じ得点が続いた場合に合格可能性が0%か100%に張り付かないようにします。
+	readinessMinStdDev = 5.0
+	// readinessZ は信頼区間 (95%) の算出に使用する標準正規分布の分位点です。
+	readinessZ = 1.96
+)
+
+// Readiness は直近の受験結果から推定した合格可能性です。
+//
+// 直近の受験ほど重みを大きくした換算スコアの加重平均と加重標準偏差から得点を正規分布で近似し、
+// 合格ラインを上回る確率を合格可能性とします。信頼区間は加重平均の標準誤差から算出します。
+type Readiness struct {
+	AttemptCount      int     `json:"attemptCount" firestore:"attempt_count"`           // 推定に使用した受験の数
+	EffectiveAttempts float64 `json:"effectiveAttempts" firestore:"effective_attempts"` // 重みを考慮した実効的な受験数
+	PassingScore      int     `json:"passingScore" firestore:"passing_score"`           // 最新の受験の合格ライン(%)
+	ExpectedScore     float64 `json:"expectedScore" firestore:"expected_score"`         // 換算スコアの加重平均 (0-100)
+	StdDev            float64 `json:"stdDev" firestore:"std_dev"`                       // 換算スコアの加重標準偏差
+	PassProbability   float64 `json:"passProbability" firestore:"pass_probability"`     // 合格可能性 (0-1)
+	LowerBound        float64 `json:"lowerBound" firestore:"lower_bound"`               // 合格可能性の95%信頼区間の下限 (0-1)
+	UpperBound        float64 `json:"upperBound" firestore:"upper_bound"`               // 合格可能性の95%信頼区間の上限 (0-1)
+}
+
+// CountsTowardReadiness は受験結果を合格可能性の推定に使用するかどうかを返します。
+// 練習形式は受験中に正解を閲覧でき、個別に生成したセットは出題が偏るため、本番形式の試験セットの受験のみを使用します。
+func (a *Attempt) CountsTowardReadiness() bool {
+	return a.Status == StatusCompleted && a.CompletedAt != nil && a.Mode == ModeExam && !a.IsGenerated()
+}
+
+// ReadinessAttempts は合格可能性の推定に使用する受験を、完了日時の新しい順に最大 ReadinessWindow 件返します。
+func ReadinessAttempts(attempts []Attempt) []Attempt {
+	recent := make([]Attempt, 0, len(attempts))
+	for _, a := range attempts {
+		if a.CountsTowardReadiness() {
+			recent = append(recent, a)
+		}
+	}
+	slices.SortFunc(recent, func(a, b Attempt) int {
+		return cmp.Compare(b.CompletedAt.UnixNano(), a.CompletedAt.UnixNano())
+	})
+	return recent[:min(len(recent), ReadinessWindow)]
+}
+
+// EstimateReadiness は完了済みの受験から合格可能性を推定します。
+// 推定に使用できる受験が ReadinessMinAttempts 件に満たない場合は false を返します。
+func EstimateReadiness(attempts []Attempt) (*Readiness, bool) {
+	recent := ReadinessAttempts(attempts)
+	if len(recent) < ReadinessMinAttempts {
+		return nil, false
+	}
+
+	weightSum, weightSqSum, mean := 0.0, 0.0, 0.0
+	weight := 1.0
+	for _, a := range recent {
+		weightSum += weight
+		weightSqSum += weight * weight
+		mean += weight * a.ScaledScore
+		weight *= ReadinessDecay
+	}
+	mean /= weightSum
+
+	variance := 0.0
+	weight = 1.0
+	for _, a := range recent {
+		variance += weight * (a.ScaledScore - mean) * (a.ScaledScore - mean)
+		weight *= ReadinessDecay
+	}
+	variance /= weightSum
+	stdDev := math.Sqrt(variance)
+
+	passingScore := recent[0].PassingScore
+	if passingScore <= 0 {
+		passingScore = DefaultPassingScore
+	}
+
+	effective := weightSum * weightSum / weightSqSum
+	sd := max(stdDev, readinessMinStdDev)
+	stdErr := sd / math.Sqrt(effective)
+	passProbability := func(m float64) float64 {
+		return roundThousandth(normalCDF((m - float64(passingScore)) / sd))
+	}
+
+	return &Readiness{
+		AttemptCount:      len(recent),
+		EffectiveAttempts: roundTenth(effective),
+		PassingScore:      passingScore,
+		ExpectedScore:     roundTenth(mean),
+		StdDev:            roundTenth(stdDev),
+		PassProbability:   passProbability(mean),
+		LowerBound:        passProbability(mean - readinessZ*stdErr),
+		UpperBound:        passProbability(mean + readinessZ*stdErr),
+	}, true
+}
+
+// normalCDF は標準正規分布の累積分布関数です。
+func normalCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
+
+func roundThousandth(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
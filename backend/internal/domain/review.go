@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// LeitnerIntervals は Leitner 方式の箱ごとの復習間隔です。
+// 箱1 は翌日、正解するたびに次の箱へ進み、間隔が長くなります。
+var LeitnerIntervals = []time.Duration{
+	1 * 24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	14 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+}
+
+// ReviewItem はユーザーごと・問題ごとの間隔反復学習 (Leitner 方式) のスケジュールです。
+// 受験で不正解(未回答を含む)だった問題が登録され、以後は受験・復習のたびに更新されます。
+// Firestore Path: users/{userID}/review_items/{questionID}
+type ReviewItem struct {
+	UserID         string    `json:"userId" firestore:"user_id"`
+	QuestionID     string    `json:"questionId" firestore:"question_id"`
+	ExamID         string    `json:"examId" firestore:"exam_id"`
+	ExamSetID      string    `json:"examSetId" firestore:"exam_set_id"`
+	Domain         string    `json:"domain" firestore:"domain"`
+	Box            int       `json:"box" firestore:"box"`                  // Leitner の箱 (1 〜 len(LeitnerIntervals))
+	DueAt          time.Time `json:"dueAt" firestore:"due_at"`             // 次回の復習予定日時
+	ReviewCount    int       `json:"reviewCount" firestore:"review_count"` // 回答した回数
+	LapseCount     int       `json:"lapseCount" firestore:"lapse_count"`   // 不正解で箱1に戻った回数
+	LastReviewedAt time.Time `json:"lastReviewedAt" firestore:"last_reviewed_at"`
+	CreatedAt      time.Time `json:"createdAt" firestore:"created_at"`
+}
+
+// NewReviewItem は問題を復習対象として登録します。登録時は箱1 (翌日に復習) から始まります。
+func NewReviewItem(userID string, q Question, now time.Time) (*ReviewItem, error) {
+	if userID == "" || q.ID == "" {
+		return nil, errors.Wrap(ErrInvalidArgument, "復習項目のUserIDとQuestionIDは必須です")
+	}
+	return &ReviewItem{
+		UserID:         userID,
+		QuestionID:     q.ID,
+		ExamID:         q.ExamID,
+		ExamSetID:      q.ExamSetID,
+		Domain:         q.Domain,
+		Box:            1,
+		DueAt:          now.Add(LeitnerIntervals[0]),
+		LastReviewedAt: now,
+		CreatedAt:      now,
+	}, nil
+}
+
+// RecordOutcome は回答結果を反映し、次回の復習予定日時を更新します。
+// 正解の場合は次の箱へ進め (最後の箱に留まる)、不正解の場合は箱1に戻します。
+func (r *ReviewItem) RecordOutcome(correct bool, now time.Time) {
+	if correct {
+		r.Box = min(r.Box+1, len(LeitnerIntervals))
+	} else {
+		if r.Box > 1 {
+			r.LapseCount++
+		}
+		r.Box = 1
+	}
+	r.Box = max(r.Box, 1)
+	r.ReviewCount++
+	r.LastReviewedAt = now
+	r.DueAt = now.Add(LeitnerIntervals[r.Box-1])
+}
+
+// IsDue は復習予定日時を過ぎているかどうかを返します。
+func (r *ReviewItem) IsDue(now time.Time) bool {
+	return !now.Before(r.DueAt)
+}
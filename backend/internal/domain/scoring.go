@@ -0,0 +1,75 @@
+package domain
+
+import "math"
+
+// ScoringStrategy は採点方式を定義します。
+// 受験開始時に試験セット(未指定の場合は試験)の設定が Attempt に記録され、以後の採点に使用されます。
+//
+// tygo:enum
+type ScoringStrategy string
+
+const (
+	ScoringAllOrNothing    ScoringStrategy = "all_or_nothing"   // 完全一致のみ1点
+	ScoringPartialCredit   ScoringStrategy = "partial_credit"   // 複数選択問題は選択内容に応じて部分点
+	ScoringNegativeMarking ScoringStrategy = "negative_marking" // 不正解は減点 (未回答は0点)
+)
+
+// DefaultScoringStrategy は採点方式が指定されていない場合に使用する採点方式です。
+const DefaultScoringStrategy = ScoringAllOrNothing
+
+// NegativeMarkingPenalty は減点方式で不正解だった場合の減点幅です。
+const NegativeMarkingPenalty = 0.25
+
+// DefaultPassingScore は合格ラインが指定されていない場合に使用する合格ライン(%)です。
+const DefaultPassingScore = 70
+
+// ApplyVerdict は DomainScores から得点率・換算スコア・合否を算出して記録します。
+//
+// 換算スコアは分野ごとの得点率を DomainWeights で加重平均したものです。
+// 配点比率が未指定の場合、または出題された分野のうち1つでも配点比率がない場合は、
+// 配点比率のない分野の結果が合否から漏れないよう、得点率をそのまま使用します。
+func (a *Attempt) ApplyVerdict() {
+	totalPoints := 0.0
+	totalCount := 0
+	weighted := 0.0
+	weightSum := 0.0
+	allWeighted := len(a.DomainWeights) > 0
+	for _, ds := range a.DomainScores {
+		if ds.TotalCount == 0 {
+			continue
+		}
+		totalPoints += ds.Points
+		totalCount += ds.TotalCount
+		w := a.DomainWeights[ds.DomainName]
+		if w <= 0 {
+			allWeighted = false
+			continue
+		}
+		weighted += w * rate(ds.Points, ds.TotalCount)
+		weightSum += w
+	}
+
+	a.Percentage = rate(totalPoints, totalCount)
+	a.ScaledScore = a.Percentage
+	if allWeighted && weightSum > 0 {
+		a.ScaledScore = roundTenth(weighted / weightSum)
+	}
+
+	passingScore := a.PassingScore
+	if passingScore <= 0 {
+		passingScore = DefaultPassingScore
+	}
+	a.Passed = a.ScaledScore >= float64(passingScore)
+}
+
+// rate は得点率(0-100)を小数第1位で返します。減点方式で得点が負の場合は0とします。
+func rate(points float64, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return roundTenth(max(points, 0) / float64(count) * 100)
+}
+
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,18 @@
+// Code generated by go run scripts/gen_enum_methods.go; DO NOT EDIT.
+package domain
+
+func (ScoringStrategy) Values() []string {
+	return []string{
+		"all_or_nothing",
+		"partial_credit",
+		"negative_marking",
+	}
+}
+
+func ScoringStrategyValues() []ScoringStrategy {
+	return []ScoringStrategy{
+		"all_or_nothing",
+		"partial_credit",
+		"negative_marking",
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -11,8 +12,13 @@ type UserExamStats struct {
 	UserID                 string                 `firestore:"user_id"`
 	ExamID                 string                 `firestore:"exam_id"`
 	TotalAttempts          int                    `firestore:"total_attempts"`
-	TotalScore             int                    `firestore:"total_score"` // 全てのAttemptでの合計正解数
-	TotalQuestionsAnswered int                    `firestore:"total_questions_answered"` // 全てのAttemptでの合計問題数
+	TotalScore             int                    `firestore:"total_score"`              // 全てのAttemptでの合計正解数
+	TotalPoints            float64                `firestore:"total_points"`             // 全てのAttemptでの合計得点 (部分点・減点を含む)
+	TotalQuestionsAnswered int                    `firestore:"total_questions_answered"` // 全てのAttemptで回答した問題数の合計 (未回答を除く)
+	PassCount              int                    `firestore:"pass_count"`               // 合格したAttemptの数
+	BestScore              float64                `firestore:"best_score"`               // これまでの最高換算スコア (0-100)
+	TotalTimeSpentSeconds  int                    `firestore:"total_time_spent_seconds"` // 全てのAttemptでの合計回答時間(秒)
+	AvgTimeSeconds         float64                `firestore:"avg_time_seconds"`         // 1問あたりの平均回答時間(秒)
 	DomainStats            map[string]DomainScore `firestore:"domain_stats"`
 	LastTakenAt            time.Time              `firestore:"last_taken_at"`
 }
@@ -31,8 +37,61 @@ func NewUserExamStats(userID, examID string) (*UserExamStats, error) {
 
 // DomainScore は特定分野ごとの成績集計です。
 type DomainScore struct {
-	DomainName   string `json:"domainName" firestore:"domain_name"`
-	CorrectCount int    `json:"correctCount" firestore:"correct_count"`
-	TotalCount   int    `json:"totalCount" firestore:"total_count"`
-	AccuracyRate int    `json:"accuracyRate" firestore:"accuracy_rate"` // パーセンテージ (0-100)
+	DomainName       string  `json:"domainName" firestore:"domain_name"`
+	CorrectCount     int     `json:"correctCount" firestore:"correct_count"`
+	TotalCount       int     `json:"totalCount" firestore:"total_count"`
+	AccuracyRate     int     `json:"accuracyRate" firestore:"accuracy_rate"`          // パーセンテージ (0-100)
+	Points           float64 `json:"points" firestore:"points"`                       // 合計得点 (部分点・減点を含む)
+	ScoreRate        int     `json:"scoreRate" firestore:"score_rate"`                // 得点率のパーセンテージ (0-100)
+	TimeSpentSeconds int     `json:"timeSpentSeconds" firestore:"time_spent_seconds"` // 合計回答時間(秒)
+	AvgTimeSeconds   float64 `json:"avgTimeSeconds" firestore:"avg_time_seconds"`     // 1問あたりの平均回答時間(秒)
+}
+
+// Add は other の集計値を加算し、正答率と得点率を再計算した DomainScore を返します。
+func (d DomainScore) Add(other DomainScore) DomainScore {
+	if d.DomainName == "" {
+		d.DomainName = other.DomainName
+	}
+	d.CorrectCount += other.CorrectCount
+	d.TotalCount += other.TotalCount
+	d.Points += other.Points
+	d.TimeSpentSeconds += other.TimeSpentSeconds
+	if d.TotalCount > 0 {
+		d.AccuracyRate = int(math.Round(float64(d.CorrectCount) / float64(d.TotalCount) * 100))
+		// 減点方式では得点が負になり得るため、得点率は0を下限とします
+		d.ScoreRate = int(math.Round(max(d.Points, 0) / float64(d.TotalCount) * 100))
+		d.AvgTimeSeconds = roundTenth(float64(d.TimeSpentSeconds) / float64(d.TotalCount))
+	}
+	return d
+}
+
+// AddAttempt は完了した受験の結果を累積成績に反映します。
+func (s *UserExamStats) AddAttempt(a *Attempt) {
+	if s.DomainStats == nil {
+		s.DomainStats = make(map[string]DomainScore)
+	}
+
+	s.TotalAttempts++
+	s.TotalScore += a.CorrectCount
+	s.TotalPoints += a.Points
+	s.TotalQuestionsAnswered += a.AnsweredCount
+	if a.Passed {
+		s.PassCount++
+	}
+	s.BestScore = max(s.BestScore, a.ScaledScore)
+	if a.CompletedAt != nil && a.CompletedAt.After(s.LastTakenAt) {
+		s.LastTakenAt = *a.CompletedAt
+	}
+
+	totalCount := 0
+	for _, ds := range a.DomainScores {
+		s.DomainStats[ds.DomainName] = s.DomainStats[ds.DomainName].Add(ds)
+		s.TotalTimeSpentSeconds += ds.TimeSpentSeconds
+	}
+	for _, ds := range s.DomainStats {
+		totalCount += ds.TotalCount
+	}
+	if totalCount > 0 {
+		s.AvgTimeSeconds = roundTenth(float64(s.TotalTimeSpentSeconds) / float64(totalCount))
+	}
 }
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// FieldError は入力項目ごとの検証エラーです。
+type FieldError struct {
+	Field   string `json:"field"`   // 例: "answers.q1"
+	Message string `json:"message"` // 例: "存在しない選択肢です: e"
+}
+
+// ValidationError は複数の入力項目の検証エラーをまとめたエラーです。
+// errors.Is(err, ErrInvalidArgument) で判定できます。
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", f.Field, f.Message))
+	}
+	return "入力値が不正です (" + strings.Join(msgs, ", ") + ")"
+}
+
+// Is は ErrInvalidArgument との比較を可能にします。
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// Add は検証エラーを追加します。
+func (e *ValidationError) Add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// Merge は err が ValidationError の場合、その項目ごとのエラーを追加します。
+func (e *ValidationError) Merge(err error) {
+	var other *ValidationError
+	if errors.As(err, &other) {
+		e.Fields = append(e.Fields, other.Fields...)
+	}
+}
+
+// ErrOrNil は検証エラーが1件以上ある場合にエラーを返し、ない場合は nil を返します。
+func (e *ValidationError) ErrOrNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
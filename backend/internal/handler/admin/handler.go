@@ -1,23 +1,31 @@
 package admin
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"fmt"
+	"strconv"
 
 	"github.com/cockroachdb/errors"
+	"github.com/go-chi/chi/v5"
 
 	"nearline/backend/internal/domain"
+	"nearline/backend/internal/handler/response"
+	"nearline/backend/internal/middleware"
 	"nearline/backend/internal/usecase"
 	"nearline/backend/internal/usecase/input"
 )
 
 type AdminHandler struct {
-	usecase usecase.QuestionUsecase
+	usecase             usecase.QuestionUsecase
+	statsRebuildUsecase usecase.StatsRebuildUsecase
+	itemAnalysisUsecase usecase.ItemAnalysisUsecase
+	examUsecase         usecase.ExamUsecase
 }
 
-func NewAdminHandler(u usecase.QuestionUsecase) *AdminHandler {
-	return &AdminHandler{usecase: u}
+func NewAdminHandler(u usecase.QuestionUsecase, statsRebuildUsecase usecase.StatsRebuildUsecase, itemAnalysisUsecase usecase.ItemAnalysisUsecase, examUsecase usecase.ExamUsecase) *AdminHandler {
+	return &AdminHandler{usecase: u, statsRebuildUsecase: statsRebuildUsecase, itemAnalysisUsecase: itemAnalysisUsecase, examUsecase: examUsecase}
 }
 
 func (h *AdminHandler) UploadQuestions(w http.ResponseWriter, r *http.Request) {
@@ -42,6 +50,10 @@ func (h *AdminHandler) UploadQuestions(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "試験セットが見つかりませんでした", http.StatusNotFound)
+			return
+		}
 		// Log the full error with stack trace for internal errors
 		fmt.Printf("internal server error: %+v\n", err)
 		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
@@ -51,3 +63,535 @@ func (h *AdminHandler) UploadQuestions(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 	w.Write([]byte(`{"status":"ok"}`))
 }
+
+// RebuildStats は完了済みの受験履歴から、ユーザーの試験ごとの累積成績を再集計します。
+// dryRun が true の場合は保存せず、変更される項目の差分のみを返します。
+func (h *AdminHandler) RebuildStats(w http.ResponseWriter, r *http.Request) {
+	examID := chi.URLParam(r, "examID")
+
+	var req input.RebuildStatsRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "リクエストボディが無効です", http.StatusBadRequest)
+			return
+		}
+	}
+
+	input, err := input.NewRebuildStats(examID, req.UserID, req.DryRun)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	result, err := h.statsRebuildUsecase.RebuildStats(r.Context(), input)
+	if err != nil {
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// GetItemAnalysis は試験の問題ごとの項目分析 (難易度・識別力・懸念点) を返します。
+// デフォルトでは懸念点のある問題のみを返し、all=true の場合はすべての問題を返します。
+func (h *AdminHandler) GetItemAnalysis(w http.ResponseWriter, r *http.Request) {
+	examID := chi.URLParam(r, "examID")
+
+	query := r.URL.Query()
+	minResponses := 0
+	if m := query.Get("minResponses"); m != "" {
+		parsed, err := strconv.Atoi(m)
+		if err != nil {
+			http.Error(w, "minResponsesは数値で指定してください", http.StatusBadRequest)
+			return
+		}
+		minResponses = parsed
+	}
+
+	input, err := input.NewGetItemAnalysis(examID, minResponses, query.Get("all") == "true")
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	analysis, err := h.itemAnalysisUsecase.GetItemAnalysis(r.Context(), input)
+	if err != nil {
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(analysis)
+}
+
+// GetQuestion は正解・解説を含む問題を1件返します。
+func (h *AdminHandler) GetQuestion(w http.ResponseWriter, r *http.Request) {
+	input, err := input.NewGetQuestion(chi.URLParam(r, "questionID"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	question, err := h.usecase.GetQuestion(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "問題が見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(question)
+}
+
+// UpdateQuestion は問題の指定された項目を更新します。省略した項目は変更しません。
+func (h *AdminHandler) UpdateQuestion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+
+	var req input.UpdateQuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "リクエストボディが無効です", http.StatusBadRequest)
+		return
+	}
+
+	input, err := input.NewUpdateQuestion(chi.URLParam(r, "questionID"), userID, req)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	question, err := h.usecase.UpdateQuestion(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "問題が見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			response.WriteInvalidArgument(w, err)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(question)
+}
+
+// DeleteQuestion は問題を削除します。
+func (h *AdminHandler) DeleteQuestion(w http.ResponseWriter, r *http.Request) {
+	input, err := input.NewGetQuestion(chi.URLParam(r, "questionID"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.usecase.DeleteQuestion(r.Context(), input); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "問題が見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetQuestionRevisions は問題の版の履歴を、各版での変更内容とともに返します。
+func (h *AdminHandler) GetQuestionRevisions(w http.ResponseWriter, r *http.Request) {
+	input, err := input.NewGetQuestion(chi.URLParam(r, "questionID"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	history, err := h.usecase.GetQuestionRevisions(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "問題が見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+// CreateExamSet は問題を含まない試験セットを作成します。
+func (h *AdminHandler) CreateExamSet(w http.ResponseWriter, r *http.Request) {
+	var req input.CreateExamSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "リクエストボディが無効です", http.StatusBadRequest)
+		return
+	}
+
+	input, err := input.NewCreateExamSet(chi.URLParam(r, "examID"), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	examSet, err := h.examUsecase.CreateExamSet(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			response.WriteInvalidArgument(w, err)
+			return
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "試験が見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(examSet)
+}
+
+// UpdateExamSet は試験セットの指定された項目を更新します。省略した項目は変更しません。
+func (h *AdminHandler) UpdateExamSet(w http.ResponseWriter, r *http.Request) {
+	var req input.UpdateExamSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "リクエストボディが無効です", http.StatusBadRequest)
+		return
+	}
+
+	input, err := input.NewUpdateExamSet(chi.URLParam(r, "examID"), chi.URLParam(r, "examSetID"), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	examSet, err := h.examUsecase.UpdateExamSet(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			response.WriteInvalidArgument(w, err)
+			return
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "試験セットが見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(examSet)
+}
+
+// DeleteExamSet は問題を含まない試験セットを削除します。
+func (h *AdminHandler) DeleteExamSet(w http.ResponseWriter, r *http.Request) {
+	input, err := input.NewGetExamSet(chi.URLParam(r, "examID"), chi.URLParam(r, "examSetID"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.examUsecase.DeleteExamSet(r.Context(), input); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "試験セットが見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrFailedPrecondition) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddExamSetQuestions は試験の問題を試験セットの末尾に追加します。他のセットの問題は移動します。
+func (h *AdminHandler) AddExamSetQuestions(w http.ResponseWriter, r *http.Request) {
+	var req input.ExamSetQuestionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "リクエストボディが無効です", http.StatusBadRequest)
+		return
+	}
+	h.updateExamSetQuestions(w, r, req.QuestionIDs, h.examUsecase.AddExamSetQuestions)
+}
+
+// RemoveExamSetQuestion は問題を試験セットから取り除きます。問題自体は削除しません。
+func (h *AdminHandler) RemoveExamSetQuestion(w http.ResponseWriter, r *http.Request) {
+	h.updateExamSetQuestions(w, r, []string{chi.URLParam(r, "questionID")}, h.examUsecase.RemoveExamSetQuestions)
+}
+
+// ReorderExamSetQuestions は試験セットの問題の出題順を、指定された問題IDの順に並べ替えます。
+func (h *AdminHandler) ReorderExamSetQuestions(w http.ResponseWriter, r *http.Request) {
+	var req input.ExamSetQuestionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "リクエストボディが無効です", http.StatusBadRequest)
+		return
+	}
+	h.updateExamSetQuestions(w, r, req.QuestionIDs, h.examUsecase.ReorderExamSetQuestions)
+}
+
+// updateExamSetQuestions は試験セットの問題を変更する操作の共通処理です。
+func (h *AdminHandler) updateExamSetQuestions(
+	w http.ResponseWriter,
+	r *http.Request,
+	questionIDs []string,
+	update func(ctx context.Context, input *input.UpdateExamSetQuestions) (*domain.ExamSet, error),
+) {
+	input, err := input.NewUpdateExamSetQuestions(chi.URLParam(r, "examID"), chi.URLParam(r, "examSetID"), questionIDs)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	examSet, err := update(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			response.WriteInvalidArgument(w, err)
+			return
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "試験セットが見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(examSet)
+}
+
+// ListExams は提供を終了した試験を含むすべての試験を表示順に返します。
+func (h *AdminHandler) ListExams(w http.ResponseWriter, r *http.Request) {
+	exams, err := h.examUsecase.ListAllExams(r.Context())
+	if err != nil {
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(exams)
+}
+
+// CreateExam は試験を作成します。試験コードが他の試験と重複する場合は400を返します。
+func (h *AdminHandler) CreateExam(w http.ResponseWriter, r *http.Request) {
+	var req input.CreateExamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "リクエストボディが無効です", http.StatusBadRequest)
+		return
+	}
+
+	input, err := input.NewCreateExam(req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	exam, err := h.examUsecase.CreateExam(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			response.WriteInvalidArgument(w, err)
+			return
+		}
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(exam)
+}
+
+// UpdateExam は試験の指定された項目を更新します。省略した項目は変更しません。
+func (h *AdminHandler) UpdateExam(w http.ResponseWriter, r *http.Request) {
+	var req input.UpdateExamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "リクエストボディが無効です", http.StatusBadRequest)
+		return
+	}
+
+	input, err := input.NewUpdateExam(chi.URLParam(r, "examID"), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	exam, err := h.examUsecase.UpdateExam(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			response.WriteInvalidArgument(w, err)
+			return
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "試験が見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrFailedPrecondition) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(exam)
+}
+
+// ReorderExams は試験の表示順を、指定された試験IDの順に並べ替えます。
+func (h *AdminHandler) ReorderExams(w http.ResponseWriter, r *http.Request) {
+	var req input.ReorderExamsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "リクエストボディが無効です", http.StatusBadRequest)
+		return
+	}
+
+	input, err := input.NewReorderExams(req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	exams, err := h.examUsecase.ReorderExams(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			response.WriteInvalidArgument(w, err)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(exams)
+}
+
+// RetireExam は試験の提供を終了します。
+func (h *AdminHandler) RetireExam(w http.ResponseWriter, r *http.Request) {
+	input, err := input.NewGetExam(chi.URLParam(r, "examID"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	exam, err := h.examUsecase.RetireExam(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "試験が見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(exam)
+}
@@ -4,11 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/go-chi/chi/v5"
 
 	"nearline/backend/internal/domain"
+	"nearline/backend/internal/handler/response"
 	"nearline/backend/internal/middleware"
 	"nearline/backend/internal/usecase"
 	"nearline/backend/internal/usecase/input"
@@ -20,26 +23,39 @@ type ClientHandler struct {
 	statsUsecase    usecase.StatsUsecase
 	examUsecase     usecase.ExamUsecase
 	userUsecase     usecase.UserUsecase
+	reviewUsecase   usecase.ReviewUsecase
+	mistakeUsecase  usecase.MistakeUsecase
 }
 
-func NewClientHandler(qu usecase.QuestionUsecase, au usecase.AttemptUsecase, su usecase.StatsUsecase, eu usecase.ExamUsecase, uu usecase.UserUsecase) *ClientHandler {
+func NewClientHandler(qu usecase.QuestionUsecase, au usecase.AttemptUsecase, su usecase.StatsUsecase, eu usecase.ExamUsecase, uu usecase.UserUsecase, ru usecase.ReviewUsecase, mu usecase.MistakeUsecase) *ClientHandler {
 	return &ClientHandler{
 		questionUsecase: qu,
 		attemptUsecase:  au,
 		statsUsecase:    su,
 		examUsecase:     eu,
 		userUsecase:     uu,
+		reviewUsecase:   ru,
+		mistakeUsecase:  mu,
 	}
 }
 
 func (h *ClientHandler) GetQuestions(w http.ResponseWriter, r *http.Request) {
 	// Go 1.22ではパス値を取得できますが、ここではChiを使用しています。
 	// パターン: /exams/{examID}/sets/{examSetID}/questions
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
 	examID := chi.URLParam(r, "examID")
 	examSetID := chi.URLParam(r, "examSetID")
 
-	input, err := input.NewGetExamQuestions(examSetID)
+	input, err := input.NewGetExamQuestions(userID, examID, examSetID)
 	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, domain.ErrInvalidArgument) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
@@ -51,6 +67,10 @@ func (h *ClientHandler) GetQuestions(w http.ResponseWriter, r *http.Request) {
 
 	questions, err := h.questionUsecase.GetExamQuestions(r.Context(), input)
 	if err != nil {
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			writePermissionDenied(w, err)
+			return
+		}
 		if errors.Is(err, domain.ErrNotFound) {
 			http.Error(w, "問題が見つかりませんでした", http.StatusNotFound)
 			return
@@ -83,8 +103,12 @@ func (h *ClientHandler) StartAttempt(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	attempt, err := h.attemptUsecase.StartAttempt(r.Context(), userID, req)
+	input, err := input.NewCreateAttempt(userID, req)
 	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
 		if errors.Is(err, domain.ErrInvalidArgument) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
@@ -94,6 +118,29 @@ func (h *ClientHandler) StartAttempt(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	attempt, err := h.attemptUsecase.StartAttempt(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrFailedPrecondition) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			writePermissionDenied(w, err)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(attempt)
@@ -114,6 +161,10 @@ func (h *ClientHandler) UpdateAttempt(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.attemptUsecase.UpdateAttempt(r.Context(), userID, attemptID, req); err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			response.WriteInvalidArgument(w, err)
+			return
+		}
 		if errors.Is(err, domain.ErrFailedPrecondition) {
 			http.Error(w, err.Error(), http.StatusConflict)
 			return
@@ -159,6 +210,56 @@ func (h *ClientHandler) CompleteAttempt(w http.ResponseWriter, r *http.Request)
 
 	attempt, err := h.attemptUsecase.CompleteAttempt(r.Context(), input)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			response.WriteInvalidArgument(w, err)
+			return
+		}
+		if errors.Is(err, domain.ErrFailedPrecondition) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			writePermissionDenied(w, err)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attempt)
+}
+
+func (h *ClientHandler) PauseAttempt(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+	attemptID := chi.URLParam(r, "attemptID")
+
+	input, err := input.NewTransitionAttempt(userID, attemptID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	attempt, err := h.attemptUsecase.PauseAttempt(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "受験データが見つかりませんでした", http.StatusNotFound)
+			return
+		}
 		if errors.Is(err, domain.ErrFailedPrecondition) {
 			http.Error(w, err.Error(), http.StatusConflict)
 			return
@@ -172,6 +273,314 @@ func (h *ClientHandler) CompleteAttempt(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(attempt)
 }
 
+func (h *ClientHandler) ResumeAttempt(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+	attemptID := chi.URLParam(r, "attemptID")
+
+	input, err := input.NewTransitionAttempt(userID, attemptID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	attempt, err := h.attemptUsecase.ResumeAttempt(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "受験データが見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrFailedPrecondition) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attempt)
+}
+
+func (h *ClientHandler) AbandonAttempt(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+	attemptID := chi.URLParam(r, "attemptID")
+
+	input, err := input.NewTransitionAttempt(userID, attemptID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	attempt, err := h.attemptUsecase.AbandonAttempt(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "受験データが見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrFailedPrecondition) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attempt)
+}
+
+func (h *ClientHandler) ListAttempts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	var statuses []string
+	if s := query.Get("status"); s != "" {
+		statuses = strings.Split(s, ",")
+	}
+	limit := 0
+	if l := query.Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil {
+			http.Error(w, "limitは数値で指定してください", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	input, err := input.NewListAttempts(userID, query.Get("examId"), query.Get("examSetId"), statuses, query.Get("sort"), limit, query.Get("cursor"))
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	attempts, err := h.attemptUsecase.ListAttempts(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attempts)
+}
+
+func (h *ClientHandler) GetAttempt(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+	attemptID := chi.URLParam(r, "attemptID")
+
+	input, err := input.NewGetAttempt(userID, attemptID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	attempt, err := h.attemptUsecase.GetAttempt(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "受験データが見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(attempt)
+}
+
+func (h *ClientHandler) GetAttemptQuestions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+	attemptID := chi.URLParam(r, "attemptID")
+
+	input, err := input.NewGetAttempt(userID, attemptID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	questions, err := h.attemptUsecase.GetAttemptQuestions(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "受験データが見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			writePermissionDenied(w, err)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(questions)
+}
+
+func (h *ClientHandler) GetAttemptAnswers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+	attemptID := chi.URLParam(r, "attemptID")
+
+	input, err := input.NewGetAttempt(userID, attemptID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	questions, err := h.attemptUsecase.GetAttemptAnswers(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "受験データが見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrFailedPrecondition) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			writePermissionDenied(w, err)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(questions)
+}
+
+func (h *ClientHandler) GetAttemptReview(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+	attemptID := chi.URLParam(r, "attemptID")
+
+	input, err := input.NewGetAttempt(userID, attemptID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	review, err := h.attemptUsecase.GetAttemptReview(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "受験データが見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrFailedPrecondition) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			writePermissionDenied(w, err)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(review)
+}
+
 func (h *ClientHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r.Context())
 	if !ok {
@@ -206,6 +615,182 @@ func (h *ClientHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(stats)
 }
 
+func (h *ClientHandler) GetReviewQueue(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	limit := 0
+	if l := query.Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil {
+			http.Error(w, "limitは数値で指定してください", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	input, err := input.NewGetReviewQueue(userID, query.Get("examId"), limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	queue, err := h.reviewUsecase.GetReviewQueue(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			writePermissionDenied(w, err)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(queue)
+}
+
+func (h *ClientHandler) RecordReview(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+	questionID := chi.URLParam(r, "questionID")
+
+	var req input.RecordReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "リクエストボディが無効です", http.StatusBadRequest)
+		return
+	}
+
+	input, err := input.NewRecordReview(userID, questionID, req.SelectedAnswers)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	result, err := h.reviewUsecase.RecordReview(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, "復習対象の問題が見つかりませんでした", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrFailedPrecondition) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			response.WriteInvalidArgument(w, err)
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			writePermissionDenied(w, err)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (h *ClientHandler) ListMistakes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	input, err := input.NewListMistakes(userID, query.Get("examId"), query.Get("domain"))
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	mistakes, err := h.mistakeUsecase.ListMistakes(r.Context(), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			writePermissionDenied(w, err)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(mistakes)
+}
+
+func (h *ClientHandler) GetScoreTrend(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		http.Error(w, "認証されていません", http.StatusUnauthorized)
+		return
+	}
+	examID := chi.URLParam(r, "examID")
+
+	input, err := input.NewGetUserExamStats(userID, examID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidArgument) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	trend, err := h.statsUsecase.GetScoreTrend(r.Context(), input)
+	if err != nil {
+		fmt.Printf("internal server error: %+v\n", err)
+		http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(trend)
+}
+
 func (h *ClientHandler) ListExams(w http.ResponseWriter, r *http.Request) {
 	exams, err := h.examUsecase.ListExams(r.Context())
 	if err != nil {
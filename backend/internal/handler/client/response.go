@@ -0,0 +1,24 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	"nearline/backend/internal/domain"
+)
+
+// writePermissionDenied は権限エラーを403で返します。
+// domain.AccessDeniedError の場合は、フロントエンドがアップグレード導線を出し分けられるよう理由コードを含めます。
+func writePermissionDenied(w http.ResponseWriter, err error) {
+	body := map[string]string{"error": "この試験へのアクセス権限がありません"}
+	var accessErr *domain.AccessDeniedError
+	if errors.As(err, &accessErr) {
+		body["reason"] = string(accessErr.Reason)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,28 @@
+// Package response は管理画面・クライアントの両方のハンドラーで共通のエラーレスポンスを提供します。
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	"nearline/backend/internal/domain"
+)
+
+// WriteInvalidArgument は入力エラーを400で返します。
+// domain.ValidationError の場合は、項目ごとのエラー一覧を含めます。
+func WriteInvalidArgument(w http.ResponseWriter, err error) {
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]any{
+		"error":  "入力内容が不正です",
+		"fields": validationErr.Fields,
+	})
+}
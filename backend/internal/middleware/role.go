@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/usecase"
+)
+
+const UserKey contextKey = "user"
+
+// RequireRole は呼び出し元ユーザーのロールが roles のいずれかであることを要求するミドルウェアを返します。
+// UIDを参照するため、AuthMiddleware の後に適用してください。
+// 解決したユーザーはコンテキストに格納され、GetUser で取得できます。
+func RequireRole(userUsecase usecase.UserUsecase, roles ...domain.UserRole) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := GetUserID(r.Context())
+			if !ok {
+				http.Error(w, "認証されていません", http.StatusUnauthorized)
+				return
+			}
+
+			user, err := userUsecase.GetUser(r.Context(), userID)
+			if err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					http.Error(w, "この操作を行う権限がありません", http.StatusForbidden)
+					return
+				}
+				fmt.Printf("internal server error: %+v\n", err)
+				http.Error(w, "サーバー内部エラーが発生しました", http.StatusInternalServerError)
+				return
+			}
+
+			if !lo.Contains(roles, user.Role) {
+				http.Error(w, "この操作を行う権限がありません", http.StatusForbidden)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), UserKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetUser は RequireRole によって解決されたユーザーをコンテキストから取得します。
+func GetUser(ctx context.Context) (*domain.User, bool) {
+	user, ok := ctx.Value(UserKey).(*domain.User)
+	return user, ok
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"nearline/backend/internal/domain"
+)
+
+type MockUserUsecase struct {
+	mock.Mock
+}
+
+func (m *MockUserUsecase) CreateUser(ctx context.Context, id string, email string, provider domain.AuthProvider) (*domain.User, error) {
+	args := m.Called(ctx, id, email, provider)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserUsecase) GetUser(ctx context.Context, id string) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string // 空の場合は認証済みのユーザーIDをコンテキストに含めない
+		user       *domain.User
+		findErr    error
+		wantStatus int
+		wantNext   bool
+	}{
+		{
+			name:       "認証されていない場合は401",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "無料ユーザーは403",
+			userID:     "user-1",
+			user:       &domain.User{ID: "user-1", Role: domain.RoleFree},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "管理者は通過し、ユーザーをコンテキストに格納する",
+			userID:     "admin-1",
+			user:       &domain.User{ID: "admin-1", Role: domain.RoleAdmin},
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+		},
+		{
+			name:       "ユーザーが登録されていない場合は403",
+			userID:     "user-1",
+			findErr:    errors.Wrap(domain.ErrNotFound, "ユーザーが見つかりませんでした"),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "ユーザーの取得に失敗した場合は500",
+			userID:     "user-1",
+			findErr:    errors.New("firestore unavailable"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserUsecase := new(MockUserUsecase)
+			if tt.userID != "" {
+				mockUserUsecase.On("GetUser", mock.Anything, tt.userID).Return(tt.user, tt.findErr)
+			}
+
+			var nextCalled bool
+			var resolved *domain.User
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				resolved, _ = GetUser(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/questions", nil)
+			if tt.userID != "" {
+				req = req.WithContext(context.WithValue(req.Context(), UserIDKey, tt.userID))
+			}
+			rec := httptest.NewRecorder()
+			RequireRole(mockUserUsecase, domain.RoleAdmin)(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantNext, nextCalled)
+			if tt.wantNext {
+				assert.Equal(t, tt.user, resolved)
+			}
+			if tt.userID == "" {
+				mockUserUsecase.AssertNotCalled(t, "GetUser", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
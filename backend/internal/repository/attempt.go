@@ -10,4 +10,26 @@ import (
 type AttemptRepository interface {
 	Save(ctx context.Context, attempt domain.Attempt) error
 	Find(ctx context.Context, attemptID string, userID string) (*domain.Attempt, error)
+	// FindByUser はユーザーの受験履歴を新しい順に取得します。
+	// 続きのページが存在する場合は、次ページ取得用のカーソルを返します。
+	FindByUser(ctx context.Context, query AttemptQuery) ([]domain.Attempt, string, error)
+}
+
+// AttemptOrder は受験履歴の並び替えに使用する項目です。
+type AttemptOrder string
+
+const (
+	AttemptOrderUpdatedAt AttemptOrder = "updatedAt"
+	AttemptOrderStartedAt AttemptOrder = "startedAt"
+)
+
+// AttemptQuery は受験履歴の検索条件です。空の項目は絞り込みに使用しません。
+type AttemptQuery struct {
+	UserID    string
+	ExamID    string
+	ExamSetID string
+	Statuses  []domain.AttemptStatus
+	OrderBy   AttemptOrder
+	Limit     int
+	Cursor    string // 前ページで返されたカーソル。空の場合は先頭から取得
 }
@@ -10,5 +10,20 @@ import (
 type ExamRepository interface {
 	FindAll(ctx context.Context) ([]domain.Exam, error)
 	Find(ctx context.Context, id string) (*domain.Exam, error)
+	// Create は試験を作成します。同じIDの試験が存在する場合は domain.ErrAlreadyExists を返します。
+	Create(ctx context.Context, exam domain.Exam) error
+	Save(ctx context.Context, exam domain.Exam) error
+	// ReserveCode は試験コードを examID の試験に予約します。大文字・小文字を区別せず、
+	// 他の試験に予約されている場合は domain.ErrAlreadyExists を返します。examID の試験に予約済みの場合は何もしません。
+	// トランザクション内では予約の確認と作成を不可分に行います。
+	ReserveCode(ctx context.Context, code, examID string) error
+	// ReleaseCode は examID の試験による試験コードの予約を解除します。予約されていない場合や、他の試験の予約の場合は何もしません。
+	ReleaseCode(ctx context.Context, code, examID string) error
 	FindSets(ctx context.Context, examID string) ([]domain.ExamSet, error)
+	FindSet(ctx context.Context, examID, examSetID string) (*domain.ExamSet, error)
+	// CreateSet は試験セットを作成します。同じIDのセットが存在する場合は domain.ErrAlreadyExists を返します。
+	CreateSet(ctx context.Context, examSet domain.ExamSet) error
+	SaveSet(ctx context.Context, examSet domain.ExamSet) error
+	// DeleteSet は試験セットを削除します。存在しない場合は domain.ErrNotFound を返します。
+	DeleteSet(ctx context.Context, examID, examSetID string) error
 }
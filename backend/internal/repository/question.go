@@ -9,5 +9,19 @@ import (
 // QuestionRepository は問題エンティティの永続化を管理します。
 type QuestionRepository interface {
 	BulkCreate(ctx context.Context, questions []domain.Question) error
-	FindByExamSetID(ctx context.Context, examSetID string) ([]domain.Question, error)
+	// FindByExamSetID は試験セットの問題を返します。試験セットIDは試験をまたいで重複しうるため、試験IDでも絞り込みます。
+	FindByExamSetID(ctx context.Context, examID, examSetID string) ([]domain.Question, error)
+	FindByExamID(ctx context.Context, examID string) ([]domain.Question, error)
+	// FindByIDs は指定されたIDの問題を返します。存在しない問題は結果に含まれません。
+	FindByIDs(ctx context.Context, ids []string) ([]domain.Question, error)
+	// Find は問題を1件返します。存在しない場合は domain.ErrNotFound を返します。
+	Find(ctx context.Context, id string) (*domain.Question, error)
+	Save(ctx context.Context, question domain.Question) error
+	// Delete は問題を削除します。存在しない場合は domain.ErrNotFound を返します。
+	Delete(ctx context.Context, id string) error
+	SaveRevision(ctx context.Context, revision domain.QuestionRevision) error
+	// FindRevision は問題の指定された版を返します。存在しない場合は domain.ErrNotFound を返します。
+	FindRevision(ctx context.Context, questionID string, revision int) (*domain.QuestionRevision, error)
+	// FindRevisions は問題の記録済みの版を版番号順に返します。
+	FindRevisions(ctx context.Context, questionID string) ([]domain.QuestionRevision, error)
 }
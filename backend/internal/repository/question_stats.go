@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"nearline/backend/internal/domain"
+)
+
+// QuestionStatsRepository は問題ごとの回答統計 (項目分析) の永続化を管理します。
+type QuestionStatsRepository interface {
+	// Record は回答結果を問題ごとの集計に加算します。同時に完了した受験の結果を取りこぼさないよう、加算はアトミックに行われます。
+	Record(ctx context.Context, responses []domain.QuestionResponse) error
+	FindByExamID(ctx context.Context, examID string) ([]domain.QuestionStats, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"nearline/backend/internal/domain"
+)
+
+// ReviewItemRepository は間隔反復学習の復習項目の永続化を管理します。
+type ReviewItemRepository interface {
+	Save(ctx context.Context, item domain.ReviewItem) error
+	SaveAll(ctx context.Context, items []domain.ReviewItem) error
+	Find(ctx context.Context, userID, questionID string) (*domain.ReviewItem, error)
+	// FindByQuestionIDs は指定された問題の復習項目を返します。登録されていない問題は結果に含まれません。
+	FindByQuestionIDs(ctx context.Context, userID string, questionIDs []string) ([]domain.ReviewItem, error)
+	// FindDue は復習予定日時が now 以前の復習項目を、予定日時の古い順に最大 limit 件返します。
+	FindDue(ctx context.Context, userID, examID string, now time.Time, limit int) ([]domain.ReviewItem, error)
+}
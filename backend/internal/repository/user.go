@@ -11,4 +11,6 @@ type UserRepository interface {
 	Create(ctx context.Context, user domain.User) error
 	Find(ctx context.Context, id string) (*domain.User, error)
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	// FindAll は全ユーザーを返します。統計の再集計などの管理用途で使用します。
+	FindAll(ctx context.Context) ([]domain.User, error)
 }
@@ -2,6 +2,9 @@ package repository_impl
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"github.com/cockroachdb/errors"
@@ -59,3 +62,105 @@ func (r *attemptRepository) Find(ctx context.Context, attemptID string, userID s
 
 	return &attempt, nil
 }
+
+// FindByUser はユーザーの受験履歴を OrderBy の降順で取得します。
+// 絞り込み条件と並び順の組み合わせごとに Firestore の複合インデックスが必要です。
+func (r *attemptRepository) FindByUser(ctx context.Context, query repository.AttemptQuery) ([]domain.Attempt, string, error) {
+	if query.UserID == "" {
+		return nil, "", errors.New("UserIDは必須です")
+	}
+
+	order := repository.AttemptOrderUpdatedAt
+	field := "updated_at"
+	if query.OrderBy == repository.AttemptOrderStartedAt {
+		order = repository.AttemptOrderStartedAt
+		field = "started_at"
+	}
+
+	q := r.client.Collection("users").Doc(query.UserID).Collection("attempts").Query
+	if query.ExamID != "" {
+		q = q.Where("exam_id", "==", query.ExamID)
+	}
+	if query.ExamSetID != "" {
+		q = q.Where("exam_set_id", "==", query.ExamSetID)
+	}
+	switch len(query.Statuses) {
+	case 0:
+	case 1:
+		q = q.Where("status", "==", query.Statuses[0])
+	default:
+		q = q.Where("status", "in", query.Statuses)
+	}
+	q = q.OrderBy(field, firestore.Desc).OrderBy(firestore.DocumentID, firestore.Desc)
+
+	if query.Cursor != "" {
+		c, err := decodeAttemptCursor(query.Cursor, order)
+		if err != nil {
+			return nil, "", err
+		}
+		q = q.StartAfter(c.Time, c.ID)
+	}
+
+	// 次ページの有無を判定するため1件多く取得する
+	docs, err := q.Limit(query.Limit + 1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "firestore: attempt一覧の取得に失敗しました")
+	}
+
+	attempts := make([]domain.Attempt, 0, len(docs))
+	for _, doc := range docs {
+		var attempt domain.Attempt
+		if err := doc.DataTo(&attempt); err != nil {
+			return nil, "", errors.Wrap(err, "firestore: attemptのデータマッピングに失敗しました")
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	if len(attempts) <= query.Limit {
+		return attempts, "", nil
+	}
+
+	attempts = attempts[:query.Limit]
+	last := attempts[len(attempts)-1]
+	cursorTime := last.UpdatedAt
+	if order == repository.AttemptOrderStartedAt {
+		cursorTime = last.StartedAt
+	}
+	nextCursor, err := encodeAttemptCursor(attemptCursor{Order: order, Time: cursorTime, ID: last.ID})
+	if err != nil {
+		return nil, "", err
+	}
+	return attempts, nextCursor, nil
+}
+
+// attemptCursor はページングの再開位置を表します。クライアントには不透明な文字列として渡します。
+type attemptCursor struct {
+	Order repository.AttemptOrder `json:"o"`
+	Time  time.Time               `json:"t"`
+	ID    string                  `json:"id"`
+}
+
+func encodeAttemptCursor(c attemptCursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", errors.Wrap(err, "カーソルのエンコードに失敗しました")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeAttemptCursor はカーソルを復元します。order と異なる並び順で発行されたカーソルは
+// 日時の意味が異なり取得結果が欠けたり重複したりするため、domain.ErrInvalidArgument を返します。
+func decodeAttemptCursor(s string, order repository.AttemptOrder) (*attemptCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "カーソルの形式が無効です")
+	}
+	var c attemptCursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" || c.Order == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "カーソルの形式が無効です")
+	}
+	if c.Order != order {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "カーソルの並び順が指定された並び順と一致しません")
+	}
+	return &c, nil
+}
@@ -0,0 +1,54 @@
+package repository_impl
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+)
+
+func TestAttemptCursor(t *testing.T) {
+	t.Run("エンコードしたカーソルを同じ並び順で復元できる", func(t *testing.T) {
+		c := attemptCursor{
+			Order: repository.AttemptOrderStartedAt,
+			Time:  time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC),
+			ID:    "attempt-1",
+		}
+
+		s, err := encodeAttemptCursor(c)
+		assert.NoError(t, err)
+		decoded, err := decodeAttemptCursor(s, repository.AttemptOrderStartedAt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, c, *decoded)
+	})
+
+	t.Run("別の並び順で発行されたカーソルは使用できない", func(t *testing.T) {
+		s, _ := encodeAttemptCursor(attemptCursor{Order: repository.AttemptOrderUpdatedAt, Time: time.Now(), ID: "attempt-1"})
+
+		_, err := decodeAttemptCursor(s, repository.AttemptOrderStartedAt)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	})
+
+	malformed := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "base64 でない", cursor: "!!!"},
+		{name: "JSON でない", cursor: base64.RawURLEncoding.EncodeToString([]byte("not json"))},
+		{name: "IDがない", cursor: base64.RawURLEncoding.EncodeToString([]byte(`{"o":"updatedAt","t":"2025-01-01T00:00:00Z"}`))},
+		{name: "並び順がない", cursor: base64.RawURLEncoding.EncodeToString([]byte(`{"t":"2025-01-01T00:00:00Z","id":"attempt-1"}`))},
+	}
+	for _, tt := range malformed {
+		t.Run("不正なカーソル: "+tt.name, func(t *testing.T) {
+			_, err := decodeAttemptCursor(tt.cursor, repository.AttemptOrderUpdatedAt)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package repository_impl
 
 import (
 	"context"
+	"strings"
 
 	"cloud.google.com/go/firestore"
 	"github.com/cockroachdb/errors"
@@ -58,6 +59,127 @@ func (r *examRepository) Find(ctx context.Context, id string) (*domain.Exam, err
 	return &exam, nil
 }
 
+func (r *examRepository) Create(ctx context.Context, exam domain.Exam) error {
+	if exam.ID == "" {
+		return errors.New("試験IDは必須です")
+	}
+
+	docRef := r.client.Collection("exams").Doc(exam.ID)
+	if tx, ok := GetTransaction(ctx); ok {
+		return tx.Create(docRef, exam)
+	}
+
+	if _, err := docRef.Create(ctx, exam); err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return errors.Wrapf(domain.ErrAlreadyExists, "試験 %s は既に存在します", exam.ID)
+		}
+		return errors.Wrap(err, "試験の作成に失敗しました")
+	}
+	return nil
+}
+
+func (r *examRepository) Save(ctx context.Context, exam domain.Exam) error {
+	if exam.ID == "" {
+		return errors.New("試験IDは必須です")
+	}
+
+	docRef := r.client.Collection("exams").Doc(exam.ID)
+	if tx, ok := GetTransaction(ctx); ok {
+		return tx.Set(docRef, exam)
+	}
+
+	if _, err := docRef.Set(ctx, exam); err != nil {
+		return errors.Wrap(err, "試験の保存に失敗しました")
+	}
+	return nil
+}
+
+// examCode は試験コードの予約です。試験コードの重複を防ぐため、試験コードごとに1件作成します。
+type examCode struct {
+	ExamID string `firestore:"exam_id"`
+}
+
+func (r *examRepository) code(code string) *firestore.DocumentRef {
+	return r.client.Collection("exam_codes").Doc(strings.ToUpper(code))
+}
+
+func (r *examRepository) ReserveCode(ctx context.Context, code, examID string) error {
+	if code == "" || examID == "" {
+		return errors.New("試験コードと試験IDは必須です")
+	}
+
+	docRef := r.code(code)
+	if tx, ok := GetTransaction(ctx); ok {
+		// 予約の有無を読み取ってから作成することで、同じコードを同時に予約したトランザクションの一方を再試行させる
+		doc, err := tx.Get(docRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return tx.Create(docRef, examCode{ExamID: examID})
+			}
+			return errors.Wrap(err, "試験コードの予約の取得に失敗しました")
+		}
+		return checkCodeReservation(doc, code, examID)
+	}
+
+	if _, err := docRef.Create(ctx, examCode{ExamID: examID}); err != nil {
+		if status.Code(err) != codes.AlreadyExists {
+			return errors.Wrap(err, "試験コードの予約に失敗しました")
+		}
+		doc, err := docRef.Get(ctx)
+		if err != nil {
+			return errors.Wrap(err, "試験コードの予約の取得に失敗しました")
+		}
+		return checkCodeReservation(doc, code, examID)
+	}
+	return nil
+}
+
+// checkCodeReservation は予約済みの試験コードが examID の試験のものかを確認し、他の試験のものであれば domain.ErrAlreadyExists を返します。
+func checkCodeReservation(doc *firestore.DocumentSnapshot, code, examID string) error {
+	var reserved examCode
+	if err := doc.DataTo(&reserved); err != nil {
+		return errors.Wrap(err, "ドキュメントの変換に失敗しました")
+	}
+	if reserved.ExamID == examID {
+		return nil
+	}
+	return errors.Wrapf(domain.ErrAlreadyExists, "試験コード %s は試験 %s で使用されています", code, reserved.ExamID)
+}
+
+func (r *examRepository) ReleaseCode(ctx context.Context, code, examID string) error {
+	if code == "" || examID == "" {
+		return nil
+	}
+
+	// 他の試験の予約を解除しないよう、予約した試験を確認してから解除する
+	docRef := r.code(code)
+	doc, err := docRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		return errors.Wrap(err, "試験コードの予約の取得に失敗しました")
+	}
+	var reserved examCode
+	if err := doc.DataTo(&reserved); err != nil {
+		return errors.Wrap(err, "ドキュメントの変換に失敗しました")
+	}
+	if reserved.ExamID != examID {
+		return nil
+	}
+
+	// トランザクション内の読み取りは書き込みより前に行う必要があるため、確認後に予約が作り直されていないことは更新日時で保証する
+	precondition := firestore.LastUpdateTime(doc.UpdateTime)
+	if tx, ok := GetTransaction(ctx); ok {
+		return tx.Delete(docRef, precondition)
+	}
+
+	if _, err := docRef.Delete(ctx, precondition); err != nil {
+		return errors.Wrap(err, "試験コードの予約の解除に失敗しました")
+	}
+	return nil
+}
+
 func (r *examRepository) FindSets(ctx context.Context, examID string) ([]domain.ExamSet, error) {
 	var examSets []domain.ExamSet
 	iter := r.client.Collection("exams").Doc(examID).Collection("sets").Documents(ctx)
@@ -78,3 +200,73 @@ func (r *examRepository) FindSets(ctx context.Context, examID string) ([]domain.
 	}
 	return examSets, nil
 }
+
+func (r *examRepository) FindSet(ctx context.Context, examID, examSetID string) (*domain.ExamSet, error) {
+	doc, err := r.client.Collection("exams").Doc(examID).Collection("sets").Doc(examSetID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, errors.Wrap(domain.ErrNotFound, "試験セットが見つかりませんでした")
+		}
+		return nil, errors.Wrap(err, "試験セットの取得に失敗しました")
+	}
+
+	var examSet domain.ExamSet
+	if err := doc.DataTo(&examSet); err != nil {
+		return nil, errors.Wrap(err, "ドキュメントの変換に失敗しました")
+	}
+	return &examSet, nil
+}
+
+func (r *examRepository) sets(examID string) *firestore.CollectionRef {
+	return r.client.Collection("exams").Doc(examID).Collection("sets")
+}
+
+func (r *examRepository) CreateSet(ctx context.Context, examSet domain.ExamSet) error {
+	if examSet.ID == "" || examSet.ExamID == "" {
+		return errors.New("試験セットのIDとExamIDは必須です")
+	}
+
+	docRef := r.sets(examSet.ExamID).Doc(examSet.ID)
+	if tx, ok := GetTransaction(ctx); ok {
+		return tx.Create(docRef, examSet)
+	}
+
+	if _, err := docRef.Create(ctx, examSet); err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return errors.Wrapf(domain.ErrAlreadyExists, "試験セット %s は既に存在します", examSet.ID)
+		}
+		return errors.Wrap(err, "試験セットの作成に失敗しました")
+	}
+	return nil
+}
+
+func (r *examRepository) SaveSet(ctx context.Context, examSet domain.ExamSet) error {
+	if examSet.ID == "" || examSet.ExamID == "" {
+		return errors.New("試験セットのIDとExamIDは必須です")
+	}
+
+	docRef := r.sets(examSet.ExamID).Doc(examSet.ID)
+	if tx, ok := GetTransaction(ctx); ok {
+		return tx.Set(docRef, examSet)
+	}
+
+	if _, err := docRef.Set(ctx, examSet); err != nil {
+		return errors.Wrap(err, "試験セットの保存に失敗しました")
+	}
+	return nil
+}
+
+func (r *examRepository) DeleteSet(ctx context.Context, examID, examSetID string) error {
+	docRef := r.sets(examID).Doc(examSetID)
+	if tx, ok := GetTransaction(ctx); ok {
+		return tx.Delete(docRef, firestore.Exists)
+	}
+
+	if _, err := docRef.Delete(ctx, firestore.Exists); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return errors.Wrap(domain.ErrNotFound, "試験セットが見つかりませんでした")
+		}
+		return errors.Wrap(err, "試験セットの削除に失敗しました")
+	}
+	return nil
+}
@@ -2,9 +2,12 @@ package repository_impl
 
 import (
 	"context"
+	"strconv"
 
 	"cloud.google.com/go/firestore"
 	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"nearline/backend/internal/domain"
 	"nearline/backend/internal/repository"
@@ -19,15 +22,26 @@ func NewQuestionRepository(client *firestore.Client) repository.QuestionReposito
 }
 
 func (r *questionRepository) BulkCreate(ctx context.Context, questions []domain.Question) error {
+	tx, inTx := GetTransaction(ctx)
 	batch := r.client.Batch()
 	for _, q := range questions {
 		if q.ID == "" {
 			return errors.New("質問IDは必須です")
 		}
 		docRef := r.client.Collection("questions").Doc(q.ID)
+		if inTx {
+			if err := tx.Set(docRef, q); err != nil {
+				return errors.Wrap(err, "firestore: failed to bulk create questions")
+			}
+			continue
+		}
 		batch.Set(docRef, q)
 	}
 
+	if inTx {
+		return nil
+	}
+
 	_, err := batch.Commit(ctx)
 	if err != nil {
 		return errors.Wrap(err, "firestore: failed to bulk create questions")
@@ -36,8 +50,8 @@ func (r *questionRepository) BulkCreate(ctx context.Context, questions []domain.
 	return nil
 }
 
-func (r *questionRepository) FindByExamSetID(ctx context.Context, examSetID string) ([]domain.Question, error) {
-	iter := r.client.Collection("questions").Where("exam_set_id", "==", examSetID).Documents(ctx)
+func (r *questionRepository) FindByExamSetID(ctx context.Context, examID, examSetID string) ([]domain.Question, error) {
+	iter := r.client.Collection("questions").Where("exam_id", "==", examID).Where("exam_set_id", "==", examSetID).Documents(ctx)
 	docs, err := iter.GetAll()
 	if err != nil {
 		return nil, errors.Wrap(err, "firestore: failed to get questions")
@@ -55,3 +69,151 @@ func (r *questionRepository) FindByExamSetID(ctx context.Context, examSetID stri
 
 	return questions, nil
 }
+
+func (r *questionRepository) FindByExamID(ctx context.Context, examID string) ([]domain.Question, error) {
+	docs, err := r.client.Collection("questions").Where("exam_id", "==", examID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "firestore: failed to get questions")
+	}
+
+	questions := make([]domain.Question, 0, len(docs))
+	for _, doc := range docs {
+		var q domain.Question
+		if err := doc.DataTo(&q); err != nil {
+			return nil, errors.Wrap(err, "firestore: failed to map question data")
+		}
+		questions = append(questions, q)
+	}
+
+	return questions, nil
+}
+
+func (r *questionRepository) FindByIDs(ctx context.Context, ids []string) ([]domain.Question, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	refs := make([]*firestore.DocumentRef, 0, len(ids))
+	for _, id := range ids {
+		refs = append(refs, r.client.Collection("questions").Doc(id))
+	}
+
+	docs, err := r.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, errors.Wrap(err, "firestore: failed to get questions")
+	}
+
+	questions := make([]domain.Question, 0, len(docs))
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		var q domain.Question
+		if err := doc.DataTo(&q); err != nil {
+			return nil, errors.Wrap(err, "firestore: failed to map question data")
+		}
+		questions = append(questions, q)
+	}
+
+	return questions, nil
+}
+
+func (r *questionRepository) Find(ctx context.Context, id string) (*domain.Question, error) {
+	doc, err := r.client.Collection("questions").Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, errors.Wrap(domain.ErrNotFound, "問題が見つかりませんでした")
+		}
+		return nil, errors.Wrap(err, "firestore: failed to get question")
+	}
+
+	var q domain.Question
+	if err := doc.DataTo(&q); err != nil {
+		return nil, errors.Wrap(err, "firestore: failed to map question data")
+	}
+	return &q, nil
+}
+
+func (r *questionRepository) Save(ctx context.Context, question domain.Question) error {
+	if question.ID == "" {
+		return errors.New("質問IDは必須です")
+	}
+
+	docRef := r.client.Collection("questions").Doc(question.ID)
+	if tx, ok := GetTransaction(ctx); ok {
+		return tx.Set(docRef, question)
+	}
+
+	if _, err := docRef.Set(ctx, question); err != nil {
+		return errors.Wrap(err, "firestore: failed to save question")
+	}
+	return nil
+}
+
+func (r *questionRepository) Delete(ctx context.Context, id string) error {
+	docRef := r.client.Collection("questions").Doc(id)
+	if tx, ok := GetTransaction(ctx); ok {
+		return tx.Delete(docRef, firestore.Exists)
+	}
+
+	if _, err := docRef.Delete(ctx, firestore.Exists); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return errors.Wrap(domain.ErrNotFound, "問題が見つかりませんでした")
+		}
+		return errors.Wrap(err, "firestore: failed to delete question")
+	}
+	return nil
+}
+
+func (r *questionRepository) revisions(questionID string) *firestore.CollectionRef {
+	return r.client.Collection("questions").Doc(questionID).Collection("revisions")
+}
+
+func (r *questionRepository) SaveRevision(ctx context.Context, revision domain.QuestionRevision) error {
+	if revision.QuestionID == "" || revision.Revision <= 0 {
+		return errors.New("QuestionIDと版番号は必須です")
+	}
+
+	docRef := r.revisions(revision.QuestionID).Doc(strconv.Itoa(revision.Revision))
+	if tx, ok := GetTransaction(ctx); ok {
+		return tx.Set(docRef, revision)
+	}
+
+	if _, err := docRef.Set(ctx, revision); err != nil {
+		return errors.Wrap(err, "firestore: failed to save question revision")
+	}
+	return nil
+}
+
+func (r *questionRepository) FindRevision(ctx context.Context, questionID string, revision int) (*domain.QuestionRevision, error) {
+	doc, err := r.revisions(questionID).Doc(strconv.Itoa(revision)).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, errors.Wrapf(domain.ErrNotFound, "問題 %s の版 %d が見つかりませんでした", questionID, revision)
+		}
+		return nil, errors.Wrap(err, "firestore: failed to get question revision")
+	}
+
+	var rev domain.QuestionRevision
+	if err := doc.DataTo(&rev); err != nil {
+		return nil, errors.Wrap(err, "firestore: failed to map question revision data")
+	}
+	return &rev, nil
+}
+
+func (r *questionRepository) FindRevisions(ctx context.Context, questionID string) ([]domain.QuestionRevision, error) {
+	docs, err := r.revisions(questionID).OrderBy("revision", firestore.Asc).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "firestore: failed to get question revisions")
+	}
+
+	revisions := make([]domain.QuestionRevision, 0, len(docs))
+	for _, doc := range docs {
+		var rev domain.QuestionRevision
+		if err := doc.DataTo(&rev); err != nil {
+			return nil, errors.Wrap(err, "firestore: failed to map question revision data")
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, nil
+}
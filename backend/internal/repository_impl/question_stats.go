@@ -0,0 +1,109 @@
+package repository_impl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/cockroachdb/errors"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+)
+
+type questionStatsRepository struct {
+	client *firestore.Client
+}
+
+func NewQuestionStatsRepository(client *firestore.Client) repository.QuestionStatsRepository {
+	return &questionStatsRepository{client: client}
+}
+
+func (r *questionStatsRepository) Record(ctx context.Context, responses []domain.QuestionResponse) error {
+	if len(responses) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	tx, inTx := GetTransaction(ctx)
+	batch := r.client.Batch()
+
+	for _, res := range responses {
+		if res.QuestionID == "" {
+			return errors.New("質問IDは必須です")
+		}
+		docRef := r.client.Collection("question_stats").Doc(questionStatsID(res.QuestionID, res.Revision))
+		data := incrementsFor(res, now)
+
+		if inTx {
+			if err := tx.Set(docRef, data, firestore.MergeAll); err != nil {
+				return errors.Wrap(err, "firestore: failed to update question stats")
+			}
+			continue
+		}
+		batch.Set(docRef, data, firestore.MergeAll)
+	}
+
+	if inTx {
+		return nil
+	}
+	if _, err := batch.Commit(ctx); err != nil {
+		return errors.Wrap(err, "firestore: failed to update question stats")
+	}
+	return nil
+}
+
+// questionStatsID は問題の版ごとの統計のドキュメントIDを返します。
+// 版1の統計は、版ごとの集計の導入前に記録された統計と同じドキュメントに集計します。
+func questionStatsID(questionID string, revision int) string {
+	if revision <= 1 {
+		return questionID
+	}
+	return fmt.Sprintf("%s@%d", questionID, revision)
+}
+
+// incrementsFor は domain.QuestionStats.AddResponse と同じ加算を Firestore の Increment で表します。
+func incrementsFor(res domain.QuestionResponse, now time.Time) map[string]any {
+	data := map[string]any{
+		"question_id":    res.QuestionID,
+		"exam_id":        res.ExamID,
+		"exam_set_id":    res.ExamSetID,
+		"revision":       res.Revision,
+		"response_count": firestore.Increment(1),
+		"sum_rest":       firestore.Increment(res.RestScore),
+		"sum_rest_sq":    firestore.Increment(res.RestScore * res.RestScore),
+		"updated_at":     now,
+	}
+	if len(res.SelectedOptions) == 0 {
+		data["unanswered_count"] = firestore.Increment(1)
+	} else {
+		options := make(map[string]any, len(res.SelectedOptions))
+		for _, id := range res.SelectedOptions {
+			options[id] = firestore.Increment(1)
+		}
+		data["option_counts"] = options
+	}
+	if res.Correct {
+		data["correct_count"] = firestore.Increment(1)
+		data["sum_rest_correct"] = firestore.Increment(res.RestScore)
+	}
+	return data
+}
+
+func (r *questionStatsRepository) FindByExamID(ctx context.Context, examID string) ([]domain.QuestionStats, error) {
+	docs, err := r.client.Collection("question_stats").Where("exam_id", "==", examID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "firestore: failed to get question stats")
+	}
+
+	stats := make([]domain.QuestionStats, 0, len(docs))
+	for _, doc := range docs {
+		var s domain.QuestionStats
+		if err := doc.DataTo(&s); err != nil {
+			return nil, errors.Wrap(err, "firestore: failed to map question stats data")
+		}
+		stats = append(stats, s)
+	}
+	return stats, nil
+}
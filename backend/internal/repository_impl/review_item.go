@@ -0,0 +1,146 @@
+package repository_impl
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+)
+
+type reviewItemRepository struct {
+	client *firestore.Client
+}
+
+func NewReviewItemRepository(client *firestore.Client) repository.ReviewItemRepository {
+	return &reviewItemRepository{client: client}
+}
+
+func (r *reviewItemRepository) collection(userID string) *firestore.CollectionRef {
+	return r.client.Collection("users").Doc(userID).Collection("review_items")
+}
+
+func (r *reviewItemRepository) Save(ctx context.Context, item domain.ReviewItem) error {
+	if item.UserID == "" || item.QuestionID == "" {
+		return errors.New("UserIDとQuestionIDは必須です")
+	}
+
+	docRef := r.collection(item.UserID).Doc(item.QuestionID)
+	if tx, ok := GetTransaction(ctx); ok {
+		return tx.Set(docRef, item)
+	}
+
+	if _, err := docRef.Set(ctx, item); err != nil {
+		return errors.Wrap(err, "firestore: 復習項目の保存に失敗しました")
+	}
+	return nil
+}
+
+func (r *reviewItemRepository) SaveAll(ctx context.Context, items []domain.ReviewItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	tx, inTx := GetTransaction(ctx)
+	batch := r.client.Batch()
+	for _, item := range items {
+		if item.UserID == "" || item.QuestionID == "" {
+			return errors.New("UserIDとQuestionIDは必須です")
+		}
+		docRef := r.collection(item.UserID).Doc(item.QuestionID)
+		if inTx {
+			if err := tx.Set(docRef, item); err != nil {
+				return errors.Wrap(err, "firestore: 復習項目の保存に失敗しました")
+			}
+			continue
+		}
+		batch.Set(docRef, item)
+	}
+
+	if inTx {
+		return nil
+	}
+	if _, err := batch.Commit(ctx); err != nil {
+		return errors.Wrap(err, "firestore: 復習項目の保存に失敗しました")
+	}
+	return nil
+}
+
+func (r *reviewItemRepository) Find(ctx context.Context, userID, questionID string) (*domain.ReviewItem, error) {
+	if userID == "" || questionID == "" {
+		return nil, errors.New("UserIDとQuestionIDは必須です")
+	}
+
+	doc, err := r.collection(userID).Doc(questionID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, errors.Wrap(domain.ErrNotFound, "復習項目が見つかりませんでした")
+		}
+		return nil, errors.Wrap(err, "firestore: 復習項目の取得に失敗しました")
+	}
+
+	var item domain.ReviewItem
+	if err := doc.DataTo(&item); err != nil {
+		return nil, errors.Wrap(err, "firestore: 復習項目のデータマッピングに失敗しました")
+	}
+	return &item, nil
+}
+
+func (r *reviewItemRepository) FindByQuestionIDs(ctx context.Context, userID string, questionIDs []string) ([]domain.ReviewItem, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+
+	refs := make([]*firestore.DocumentRef, 0, len(questionIDs))
+	for _, id := range questionIDs {
+		refs = append(refs, r.collection(userID).Doc(id))
+	}
+
+	docs, err := r.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, errors.Wrap(err, "firestore: 復習項目の取得に失敗しました")
+	}
+
+	items := make([]domain.ReviewItem, 0, len(docs))
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+		var item domain.ReviewItem
+		if err := doc.DataTo(&item); err != nil {
+			return nil, errors.Wrap(err, "firestore: 復習項目のデータマッピングに失敗しました")
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *reviewItemRepository) FindDue(ctx context.Context, userID, examID string, now time.Time, limit int) ([]domain.ReviewItem, error) {
+	if userID == "" {
+		return nil, errors.New("UserIDは必須です")
+	}
+
+	q := r.collection(userID).Where("due_at", "<=", now)
+	if examID != "" {
+		q = q.Where("exam_id", "==", examID)
+	}
+	docs, err := q.OrderBy("due_at", firestore.Asc).Limit(limit).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "firestore: 復習項目の取得に失敗しました")
+	}
+
+	items := make([]domain.ReviewItem, 0, len(docs))
+	for _, doc := range docs {
+		var item domain.ReviewItem
+		if err := doc.DataTo(&item); err != nil {
+			return nil, errors.Wrap(err, "firestore: 復習項目のデータマッピングに失敗しました")
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -4,6 +4,11 @@ import (
 	"context"
 
 	"cloud.google.com/go/firestore"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"nearline/backend/internal/domain"
 	"nearline/backend/internal/repository"
 )
 
@@ -26,9 +31,14 @@ func NewTransactionRepository(client *firestore.Client) repository.TransactionRe
 }
 
 // RunはFirestoreトランザクション内で関数を実行します。
+// トランザクション内の作成はコミット時に失敗するため、既存のドキュメントとの衝突は domain.ErrAlreadyExists に変換して返します。
 func (r *transactionRepository) Run(ctx context.Context, f func(txCtx context.Context) error) error {
-	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
 		ctxWithTx := context.WithValue(ctx, transactionKey{}, tx)
 		return f(ctxWithTx)
 	})
+	if status.Code(err) == codes.AlreadyExists {
+		return errors.Wrap(domain.ErrAlreadyExists, err.Error())
+	}
+	return err
 }
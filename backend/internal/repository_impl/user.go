@@ -5,6 +5,7 @@ import (
 
 	"cloud.google.com/go/firestore"
 	"github.com/cockroachdb/errors"
+	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -64,3 +65,24 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain
 
 	return &user, nil
 }
+
+func (r *userRepository) FindAll(ctx context.Context) ([]domain.User, error) {
+	var users []domain.User
+	iter := r.client.Collection("users").Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list users")
+		}
+
+		var user domain.User
+		if err := doc.DataTo(&user); err != nil {
+			return nil, errors.Wrap(err, "failed to decode user")
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
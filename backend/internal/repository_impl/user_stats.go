@@ -43,7 +43,14 @@ func (r *userStatsRepository) Find(ctx context.Context, userID, examID string) (
 		return nil, errors.New("UserIDとExamIDは必須です")
 	}
 	docRef := r.client.Collection("users").Doc(userID).Collection("stats").Doc(examID)
-	doc, err := docRef.Get(ctx)
+	var doc *firestore.DocumentSnapshot
+	var err error
+	// トランザクション内では読み取った統計を更新する他のトランザクションと競合させるため、トランザクション経由で読み取る
+	if tx, ok := GetTransaction(ctx); ok {
+		doc, err = tx.Get(docRef)
+	} else {
+		doc, err = docRef.Get(ctx)
+	}
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
 			return nil, nil // Not found is not an error, usecase will handle it
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+)
+
+// AccessPolicy はユーザーのロールとサブスクリプション状態に基づき、試験へのアクセスを制御します。
+type AccessPolicy interface {
+	// AuthorizeExam はユーザーが試験にアクセス可能かを検証します。
+	// アクセスできない場合は domain.AccessDeniedError (ErrPermissionDenied) を返します。
+	AuthorizeExam(ctx context.Context, userID, examID string) error
+}
+
+type accessPolicy struct {
+	userRepo repository.UserRepository
+}
+
+func NewAccessPolicy(userRepo repository.UserRepository) AccessPolicy {
+	return &accessPolicy{userRepo: userRepo}
+}
+
+func (p *accessPolicy) AuthorizeExam(ctx context.Context, userID, examID string) error {
+	if userID == "" {
+		return errors.Wrap(domain.ErrUnauthenticated, "userIDは必須です")
+	}
+
+	user, err := p.userRepo.Find(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.NewAccessDeniedError(domain.ReasonUserNotRegistered, examID)
+		}
+		return errors.Wrap(err, "アクセス権限の確認時のユーザー取得に失敗しました")
+	}
+
+	return user.CheckExamAccess(examID)
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+	"nearline/backend/internal/util"
+)
+
+// adaptiveRecentAttemptLimit は習得済みの問題を判定する際に参照する、直近の完了済みの受験の最大件数です。
+const adaptiveRecentAttemptLimit = 20
+
+// buildAdaptiveSet は試験の全問題から、習得済みの問題を除いて正答率の低い分野を中心に最大 n 問を選びます。
+func (u *attemptUsecase) buildAdaptiveSet(ctx context.Context, userID, examID string, n int, now time.Time) ([]domain.Question, error) {
+	questions, err := u.qRepo.FindByExamID(ctx, examID)
+	if err != nil {
+		return nil, errors.Wrap(err, "attempt開始時の問題取得に失敗しました")
+	}
+	if len(questions) == 0 {
+		return nil, errors.Wrap(domain.ErrNotFound, "指定された試験に問題が見つかりません")
+	}
+
+	stats, err := u.sRepo.Find(ctx, userID, examID)
+	if err != nil {
+		return nil, err
+	}
+
+	mastered, err := u.masteredQuestionIDs(ctx, userID, examID, questions, now)
+	if err != nil {
+		return nil, err
+	}
+	candidates := lo.Filter(questions, func(q domain.Question, _ int) bool {
+		return !mastered[q.ID]
+	})
+	if len(candidates) == 0 {
+		return nil, errors.Wrap(domain.ErrFailedPrecondition, "出題できる問題がありません。すべての問題を習得済みです")
+	}
+
+	rng := rand.New(rand.NewPCG(uint64(now.UnixNano()), 0))
+	return domain.SelectAdaptiveQuestions(candidates, stats, n, rng), nil
+}
+
+// masteredQuestionIDs は最近習得したとみなす問題IDの集合を返します。
+// 一度でも間違えた問題は復習項目の箱が domain.MasteredReviewBox 以上の場合、
+// 一度も間違えていない問題は domain.AdaptiveMasteryWindow 以内に完了した受験で回答している場合に習得済みとします。
+func (u *attemptUsecase) masteredQuestionIDs(ctx context.Context, userID, examID string, questions []domain.Question, now time.Time) (map[string]bool, error) {
+	items, err := u.rRepo.FindByQuestionIDs(ctx, userID, util.Map(questions, func(q domain.Question) string { return q.ID }))
+	if err != nil {
+		return nil, err
+	}
+	reviewed := lo.KeyBy(items, func(item domain.ReviewItem) string { return item.QuestionID })
+
+	recent, _, err := u.aRepo.FindByUser(ctx, repository.AttemptQuery{
+		UserID:   userID,
+		ExamID:   examID,
+		Statuses: []domain.AttemptStatus{domain.StatusCompleted},
+		OrderBy:  repository.AttemptOrderStartedAt,
+		Limit:    adaptiveRecentAttemptLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mastered := make(map[string]bool)
+	for _, item := range items {
+		if item.Box >= domain.MasteredReviewBox {
+			mastered[item.QuestionID] = true
+		}
+	}
+	for _, a := range recent {
+		if a.CompletedAt == nil || now.Sub(*a.CompletedAt) > domain.AdaptiveMasteryWindow {
+			continue
+		}
+		for qID, selected := range a.Answers {
+			if _, ok := reviewed[qID]; !ok && len(selected) > 0 {
+				mastered[qID] = true
+			}
+		}
+	}
+	return mastered, nil
+}
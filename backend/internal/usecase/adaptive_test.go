@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+	"nearline/backend/internal/usecase/input"
+)
+
+func newDomainQuestions(domainName string, n int) []domain.Question {
+	questions := make([]domain.Question, n)
+	for i := range n {
+		questions[i] = domain.Question{ID: domainName + "-" + string(rune('a'+i)), Domain: domainName, CorrectAnswers: []string{"1"}}
+	}
+	return questions
+}
+
+func TestSelectAdaptiveQuestions(t *testing.T) {
+	candidates := append(append(newDomainQuestions("Compute", 10), newDomainQuestions("Storage", 10)...), newDomainQuestions("Network", 2)...)
+	stats := &domain.UserExamStats{DomainStats: map[string]domain.DomainScore{
+		"Compute": {CorrectCount: 9, TotalCount: 10}, // 正答率90%
+		"Storage": {CorrectCount: 3, TotalCount: 10}, // 正答率30%
+	}}
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	t.Run("正答率の低い分野ほど多く出題する", func(t *testing.T) {
+		selected := domain.SelectAdaptiveQuestions(candidates, stats, 10, rng)
+
+		counts := lo.CountValuesBy(selected, func(q domain.Question) string { return q.Domain })
+		assert.Len(t, selected, 10)
+		assert.Equal(t, 2, counts["Network"], "未回答の分野は候補が尽きるまで出題する")
+		assert.Greater(t, counts["Storage"], counts["Compute"])
+		assert.Positive(t, counts["Compute"], "正答率の高い分野も一定の割合で出題する")
+		assert.Len(t, lo.UniqBy(selected, func(q domain.Question) string { return q.ID }), 10)
+	})
+
+	t.Run("候補が足りない場合は候補をすべて返す", func(t *testing.T) {
+		selected := domain.SelectAdaptiveQuestions(candidates[:3], stats, 10, rng)
+		assert.Len(t, selected, 3)
+	})
+}
+
+func TestStartAttempt_AdaptiveSet(t *testing.T) {
+	ctx := context.Background()
+	user := domain.NewUser("user123", "user@example.com", domain.ProviderGoogle)
+	examID := "cloud-digital-leader"
+	now := time.Now()
+
+	questions := append(newDomainQuestions("Compute", 3), newDomainQuestions("Storage", 3)...)
+	completedAt := now.Add(-24 * time.Hour)
+	recent := domain.Attempt{
+		ID: "recent", UserID: user.ID, ExamID: examID, ExamSetID: "set1", Status: domain.StatusCompleted, CompletedAt: &completedAt,
+		Answers: map[string][]string{"Compute-a": {"1"}, "Storage-a": {"2"}},
+	}
+
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockStatsRepo := new(MockUserStatsRepository)
+	mockReviewRepo := new(MockReviewItemRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockExamRepo := new(MockExamRepository)
+	usecase := NewAttemptUsecase(mockExamRepo, mockQuestionRepo, mockAttemptRepo, mockStatsRepo, newMockQuestionStatsRepository(), mockReviewRepo, new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+	mockUserRepo.On("Find", ctx, user.ID).Return(user, nil)
+	mockAttemptRepo.On("FindByUser", ctx, repository.AttemptQuery{
+		UserID:    user.ID,
+		ExamID:    examID,
+		ExamSetID: domain.AdaptiveExamSetID,
+		Statuses:  domain.UnfinishedAttemptStatuses,
+		OrderBy:   repository.AttemptOrderUpdatedAt,
+		Limit:     maxUnfinishedAttempts,
+	}).Return([]domain.Attempt{}, "", nil)
+	mockAttemptRepo.On("FindByUser", ctx, repository.AttemptQuery{
+		UserID:   user.ID,
+		ExamID:   examID,
+		Statuses: []domain.AttemptStatus{domain.StatusCompleted},
+		OrderBy:  repository.AttemptOrderStartedAt,
+		Limit:    adaptiveRecentAttemptLimit,
+	}).Return([]domain.Attempt{recent}, "", nil)
+	mockExamRepo.On("Find", ctx, examID).Return(&domain.Exam{ID: examID, DurationMinutes: 90, PassingScore: 80}, nil)
+	mockQuestionRepo.On("FindByExamID", ctx, examID).Return(questions, nil)
+	mockStatsRepo.On("Find", ctx, user.ID, examID).Return(nil, nil)
+	mockReviewRepo.On("FindByQuestionIDs", ctx, user.ID, mock.Anything).Return([]domain.ReviewItem{
+		{QuestionID: "Storage-a", Box: 1},                        // 直近で間違えた問題は出題する
+		{QuestionID: "Storage-b", Box: domain.MasteredReviewBox}, // 復習で習得済みになった問題は除外する
+	}, nil)
+	mockAttemptRepo.On("Save", ctx, mock.AnythingOfType("domain.Attempt")).Return(nil)
+
+	in, _ := input.NewCreateAttempt(user.ID, input.CreateAttemptRequest{
+		ExamID:        examID,
+		ExamSetID:     domain.AdaptiveExamSetID,
+		Mode:          domain.ModePractice,
+		QuestionCount: 10,
+	})
+	attempt, err := usecase.StartAttempt(ctx, in)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Compute-b", "Compute-c", "Storage-a", "Storage-c"}, attempt.QuestionIDs)
+	assert.Equal(t, 4, attempt.TotalQuestions)
+	assert.Equal(t, 80, attempt.PassingScore)
+	assert.Nil(t, attempt.Deadline)
+	mockExamRepo.AssertNotCalled(t, "FindSet", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCompleteAttempt_AdaptiveSetScoresSelectedQuestions(t *testing.T) {
+	ctx := context.Background()
+	userID := "user123"
+	examID := "cloud-digital-leader"
+
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockStatsRepo := new(MockUserStatsRepository)
+	mockUserRepo := new(MockUserRepository)
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, mockStatsRepo, newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+	attempt, _ := domain.NewAttempt("attempt-1", userID, examID, domain.AdaptiveExamSetID, domain.ModePractice, 3, time.Now())
+	attempt.QuestionIDs = []string{"q3", "q1", "q2"}
+	attempt.Answers = map[string][]string{"q1": {"1"}, "q3": {"1"}}
+
+	mockUserRepo.On("Find", ctx, userID).Return(&domain.User{ID: userID, Role: domain.RoleFree}, nil)
+	mockAttemptRepo.On("Find", ctx, "attempt-1", userID).Return(attempt, nil)
+	// q2 は受験後に削除された
+	mockQuestionRepo.On("FindByIDs", ctx, []string{"q3", "q1", "q2"}).Return([]domain.Question{
+		{ID: "q1", Domain: "Compute", CorrectAnswers: []string{"1"}},
+		{ID: "q3", Domain: "Storage", CorrectAnswers: []string{"2"}},
+	}, nil)
+	mockStatsRepo.On("Find", ctx, userID, examID).Return(nil, nil)
+
+	var savedStats domain.UserExamStats
+	mockStatsRepo.On("Save", ctx, mock.MatchedBy(func(s domain.UserExamStats) bool {
+		savedStats = s
+		return true
+	})).Return(nil)
+	mockAttemptRepo.On("Save", ctx, mock.AnythingOfType("domain.Attempt")).Return(nil)
+
+	in, _ := input.NewCompleteAttempt(userID, "attempt-1", nil)
+	completed, err := usecase.CompleteAttempt(ctx, in)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, completed.CorrectCount)
+	assert.Equal(t, 2, completed.AnsweredCount)
+	assert.Equal(t, 1, savedStats.DomainStats["Compute"].CorrectCount)
+	assert.Equal(t, 1, savedStats.DomainStats["Storage"].TotalCount)
+	mockQuestionRepo.AssertNotCalled(t, "FindByExamSetID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNewCreateAttempt_QuestionCount(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            input.CreateAttemptRequest
+		wantCount      int
+		wantMode       domain.AttemptMode
+		wantInvalidArg bool
+	}{
+		{
+			name:      "通常のセットでは出題数を検証せずに無視する",
+			req:       input.CreateAttemptRequest{ExamID: "cdl", ExamSetID: "set1", QuestionCount: domain.MaxAdaptiveQuestionCount + 1},
+			wantCount: 0,
+			wantMode:  domain.ModeExam,
+		},
+		{
+			name:      "適応型のセットで省略した場合は既定の出題数とする",
+			req:       input.CreateAttemptRequest{ExamID: "cdl", ExamSetID: domain.AdaptiveExamSetID},
+			wantCount: domain.DefaultAdaptiveQuestionCount,
+			wantMode:  domain.ModeExam,
+		},
+		{
+			name:      "間違いノートのセットは常に練習形式とする",
+			req:       input.CreateAttemptRequest{ExamID: "cdl", ExamSetID: domain.MistakesExamSetID, Mode: domain.ModeExam, QuestionCount: 5},
+			wantCount: 5,
+			wantMode:  domain.ModePractice,
+		},
+		{
+			name:           "適応型のセットで上限を超える場合はエラー",
+			req:            input.CreateAttemptRequest{ExamID: "cdl", ExamSetID: domain.AdaptiveExamSetID, QuestionCount: domain.MaxAdaptiveQuestionCount + 1},
+			wantInvalidArg: true,
+		},
+		{
+			name:           "間違いノートのセットで負の値はエラー",
+			req:            input.CreateAttemptRequest{ExamID: "cdl", ExamSetID: domain.MistakesExamSetID, QuestionCount: -1},
+			wantInvalidArg: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in, err := input.NewCreateAttempt("user-1", tt.req)
+			if tt.wantInvalidArg {
+				assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCount, in.QuestionCount)
+			assert.Equal(t, tt.wantMode, in.Mode)
+		})
+	}
+}
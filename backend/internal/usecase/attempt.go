@@ -2,8 +2,8 @@ package usecase
 
 import (
 	"context"
-	"math"
 	"reflect"
+	"slices"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -14,66 +14,202 @@ import (
 	"nearline/backend/internal/repository"
 	"nearline/backend/internal/usecase/input"
 	"nearline/backend/internal/usecase/output"
+	"nearline/backend/internal/util"
 )
 
+// maxUnfinishedAttempts は受験開始時に確認する未完了の受験の最大件数です。
+const maxUnfinishedAttempts = 10
+
 type AttemptUsecase interface {
-	StartAttempt(ctx context.Context, userID string, req input.CreateAttemptRequest) (*domain.Attempt, error)
+	StartAttempt(ctx context.Context, input *input.CreateAttempt) (*output.Attempt, error)
 	UpdateAttempt(ctx context.Context, userID, attemptID string, req input.UpdateAttemptRequest) error
-	CompleteAttempt(ctx context.Context, input *input.CompleteAttempt) (*domain.Attempt, error)
+	CompleteAttempt(ctx context.Context, input *input.CompleteAttempt) (*output.Attempt, error)
+	GetAttemptQuestions(ctx context.Context, input *input.GetAttempt) ([]output.Question, error)
+	GetAttemptAnswers(ctx context.Context, input *input.GetAttempt) ([]output.QuestionWithAnswer, error)
+	GetAttemptReview(ctx context.Context, input *input.GetAttempt) (*output.AttemptReview, error)
+	ListAttempts(ctx context.Context, input *input.ListAttempts) (*output.AttemptList, error)
+	GetAttempt(ctx context.Context, input *input.GetAttempt) (*output.Attempt, error)
+	PauseAttempt(ctx context.Context, input *input.TransitionAttempt) (*output.Attempt, error)
+	ResumeAttempt(ctx context.Context, input *input.TransitionAttempt) (*output.Attempt, error)
+	AbandonAttempt(ctx context.Context, input *input.TransitionAttempt) (*output.Attempt, error)
 }
 
-
 type attemptUsecase struct {
-	qRepo  repository.QuestionRepository
-	aRepo  repository.AttemptRepository
-	sRepo  repository.UserStatsRepository
-	txRepo repository.TransactionRepository
+	examRepo repository.ExamRepository
+	qRepo    repository.QuestionRepository
+	aRepo    repository.AttemptRepository
+	sRepo    repository.UserStatsRepository
+	qsRepo   repository.QuestionStatsRepository
+	rRepo    repository.ReviewItemRepository
+	txRepo   repository.TransactionRepository
+	policy   AccessPolicy
 }
 
 func NewAttemptUsecase(
+	examRepo repository.ExamRepository,
 	qRepo repository.QuestionRepository,
 	aRepo repository.AttemptRepository,
 	sRepo repository.UserStatsRepository,
+	qsRepo repository.QuestionStatsRepository,
+	rRepo repository.ReviewItemRepository,
 	txRepo repository.TransactionRepository,
+	policy AccessPolicy,
 ) AttemptUsecase {
 	return &attemptUsecase{
-		qRepo:  qRepo,
-		aRepo:  aRepo,
-		sRepo:  sRepo,
-		txRepo: txRepo,
+		examRepo: examRepo,
+		qRepo:    qRepo,
+		aRepo:    aRepo,
+		sRepo:    sRepo,
+		qsRepo:   qsRepo,
+		rRepo:    rRepo,
+		txRepo:   txRepo,
+		policy:   policy,
 	}
 }
 
-func (u *attemptUsecase) StartAttempt(ctx context.Context, userID string, req input.CreateAttemptRequest) (*domain.Attempt, error) {
-	if userID == "" {
-		return nil, errors.Wrap(domain.ErrUnauthenticated, "userIDは必須です")
+func (u *attemptUsecase) StartAttempt(ctx context.Context, input *input.CreateAttempt) (*output.Attempt, error) {
+	if err := u.policy.AuthorizeExam(ctx, input.UserID, input.ExamID); err != nil {
+		return nil, err
+	}
+
+	// 同じセットの未完了の受験があれば、別端末からでも再開できるようにそれを返す
+	unfinished, _, err := u.aRepo.FindByUser(ctx, repository.AttemptQuery{
+		UserID:    input.UserID,
+		ExamID:    input.ExamID,
+		ExamSetID: input.ExamSetID,
+		Statuses:  domain.UnfinishedAttemptStatuses,
+		OrderBy:   repository.AttemptOrderUpdatedAt,
+		Limit:     maxUnfinishedAttempts,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "未完了の受験の取得に失敗しました")
+	}
+
+	now := time.Now()
+
+	// 制限時間を過ぎている受験は再開させず、その時点の回答で採点して終了させる
+	resumable := make([]domain.Attempt, 0, len(unfinished))
+	for i := range unfinished {
+		expired, err := u.expireIfOverdue(ctx, &unfinished[i])
+		if err != nil {
+			return nil, err
+		}
+		if !expired {
+			resumable = append(resumable, unfinished[i])
+		}
 	}
-	if req.ExamID == "" || req.ExamSetID == "" {
-		return nil, errors.Wrap(domain.ErrInvalidArgument, "examIDとexamSetIDは必須です")
+	if len(resumable) > 0 && !input.AbandonExisting {
+		// 形式の異なる受験を再開すると、本番形式で正解が表示される・練習形式で制限時間が適用されるなどの不整合が起きる
+		if resumable[0].Mode != input.Mode {
+			return nil, errors.Wrapf(domain.ErrFailedPrecondition, "このセットは%s形式で受験中です。新たに開始する場合は既存の受験を放棄してください", resumable[0].Mode)
+		}
+		return output.NewAttemptOutput(&resumable[0], now), nil
 	}
 
-	// 問題を取得して合計数を設定
-	questions, err := u.qRepo.FindByExamSetID(ctx, req.ExamSetID)
+	exam, err := u.examRepo.Find(ctx, input.ExamID)
 	if err != nil {
-		return nil, errors.Wrap(err, "attempt開始時の問題取得に失敗しました")
+		return nil, errors.Wrap(err, "attempt開始時の試験取得に失敗しました")
 	}
-	if len(questions) == 0 {
-		return nil, errors.Wrap(domain.ErrNotFound, "指定された試験セットに問題が見つかりません")
+	if exam.IsRetired() {
+		return nil, errors.Wrap(domain.ErrFailedPrecondition, "提供を終了した試験は受験できません")
+	}
+
+	var examSet *domain.ExamSet
+	var questions []domain.Question
+	switch input.ExamSetID {
+	case domain.AdaptiveExamSetID, domain.MistakesExamSetID:
+		// 個別に生成するセットは採点方式・合格ラインを試験の設定から引き継ぐ
+		examSet, err = domain.NewGeneratedExamSet(input.ExamSetID, input.ExamID)
+		if err != nil {
+			return nil, err
+		}
+		if input.ExamSetID == domain.AdaptiveExamSetID {
+			questions, err = u.buildAdaptiveSet(ctx, input.UserID, input.ExamID, input.QuestionCount, now)
+		} else {
+			questions, err = u.buildMistakesSet(ctx, input.UserID, input.ExamID, input.QuestionCount)
+		}
+		if err != nil {
+			return nil, err
+		}
+	default:
+		examSet, err = u.examRepo.FindSet(ctx, input.ExamID, input.ExamSetID)
+		if err != nil {
+			return nil, errors.Wrap(err, "attempt開始時の試験セット取得に失敗しました")
+		}
+
+		// 問題を取得して合計数を設定
+		questions, err = u.qRepo.FindByExamSetID(ctx, input.ExamID, input.ExamSetID)
+		if err != nil {
+			return nil, errors.Wrap(err, "attempt開始時の問題取得に失敗しました")
+		}
+		if len(questions) == 0 {
+			return nil, errors.Wrap(domain.ErrNotFound, "指定された試験セットに問題が見つかりません")
+		}
+		questions = examSet.SortQuestions(questions)
 	}
 
 	attemptID := uuid.NewString()
-	now := time.Now()
 
-	attempt, err := domain.NewAttempt(attemptID, userID, req.ExamID, req.ExamSetID, len(questions), now)
+	attempt, err := domain.NewAttempt(attemptID, input.UserID, input.ExamID, input.ExamSetID, input.Mode, len(questions), now)
 	if err != nil {
 		return nil, err
 	}
+	// 受験後に試験セットの問題や出題順が変更されても同じ問題で振り返り・再採点できるよう、出題順の問題IDを記録する
+	attempt.QuestionIDs = util.Map(questions, func(q domain.Question) string { return q.ID })
+	// 試験の制限時間は本来の問題数を前提としているため、個別に生成するセットには制限時間を設けない
+	if !attempt.IsGenerated() {
+		attempt.SetTimeLimit(examSet.TimeLimit(exam))
+	}
+	attempt.ScoringStrategy = examSet.ResolveScoringStrategy(exam)
+	attempt.PassingScore = examSet.ResolvePassingScore(exam)
+	attempt.DomainWeights = exam.DomainWeights
+	// 受験後に問題が編集されても出題した内容で振り返り・再採点できるよう、版番号を記録する
+	attempt.QuestionRevisions = make(map[string]int, len(questions))
+	for _, q := range questions {
+		attempt.QuestionRevisions[q.ID] = q.CurrentRevision()
+	}
 
-	if err := u.aRepo.Save(ctx, *attempt); err != nil {
+	err = u.txRepo.Run(ctx, func(txCtx context.Context) error {
+		// 一覧の取得後に別の端末で完了・放棄された受験を上書きしないよう、最新の状態を読み直してから放棄する
+		abandoned := make([]*domain.Attempt, 0, len(resumable))
+		for _, r := range resumable {
+			latest, err := u.aRepo.Find(txCtx, r.ID, r.UserID)
+			if err != nil {
+				return err
+			}
+			if latest.IsFinished() {
+				continue
+			}
+			if err := latest.Abandon(now); err != nil {
+				return err
+			}
+			abandoned = append(abandoned, latest)
+		}
+		for _, a := range abandoned {
+			if err := u.aRepo.Save(txCtx, *a); err != nil {
+				return err
+			}
+		}
+		return u.aRepo.Save(txCtx, *attempt)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return attempt, nil
+	return output.NewAttemptOutput(attempt, now), nil
+}
+
+// GetAttempt は受験データを1件返します。別端末で中断した受験を再開する際に使用します。
+// 制限時間を過ぎている場合は、その時点の回答で採点して完了させた状態を返します。
+func (u *attemptUsecase) GetAttempt(ctx context.Context, input *input.GetAttempt) (*output.Attempt, error) {
+	attempt, err := u.aRepo.Find(ctx, input.AttemptID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.expireIfOverdue(ctx, attempt); err != nil {
+		return nil, err
+	}
+	return output.NewAttemptOutput(attempt, time.Now()), nil
 }
 
 func (u *attemptUsecase) UpdateAttempt(ctx context.Context, userID, attemptID string, req input.UpdateAttemptRequest) error {
@@ -82,8 +218,23 @@ func (u *attemptUsecase) UpdateAttempt(ctx context.Context, userID, attemptID st
 		return err
 	}
 
-	if attempt.Status == domain.StatusCompleted {
-		return errors.Wrap(domain.ErrFailedPrecondition, "試験は既に完了しています")
+	if attempt.IsFinished() {
+		return errors.Wrap(domain.ErrFailedPrecondition, "試験は既に終了しています")
+	}
+
+	expired, err := u.expireIfOverdue(ctx, attempt)
+	if err != nil {
+		return err
+	}
+	if expired {
+		return errors.Wrap(domain.ErrFailedPrecondition, "制限時間を過ぎたため回答を保存できません")
+	}
+	if attempt.Status != domain.StatusInProgress {
+		return errors.Wrap(domain.ErrFailedPrecondition, "中断中の受験は再開してから回答を保存してください")
+	}
+
+	if err := u.validateSubmission(ctx, attempt, req.Answers, req.TimeSpent, req.FlaggedQuestionIDs); err != nil {
+		return err
 	}
 
 	attempt.CurrentIndex = req.CurrentIndex
@@ -93,13 +244,18 @@ func (u *attemptUsecase) UpdateAttempt(ctx context.Context, userID, attemptID st
 	for k, v := range req.Answers {
 		attempt.Answers[k] = v
 	}
+	attempt.RecordTimeSpent(req.TimeSpent)
+	if req.FlaggedQuestionIDs != nil {
+		attempt.SetFlaggedQuestions(*req.FlaggedQuestionIDs)
+	}
 	attempt.UpdatedAt = time.Now()
 
 	return u.aRepo.Save(ctx, *attempt)
 }
 
-func (u *attemptUsecase) CompleteAttempt(ctx context.Context, input *input.CompleteAttempt) (*domain.Attempt, error) {
+func (u *attemptUsecase) CompleteAttempt(ctx context.Context, input *input.CompleteAttempt) (*output.Attempt, error) {
 	var completedAttempt *domain.Attempt
+	now := time.Now()
 
 	err := u.txRepo.Run(ctx, func(txCtx context.Context) error {
 		attempt, err := u.aRepo.Find(txCtx, input.AttemptID, input.UserID)
@@ -107,36 +263,33 @@ func (u *attemptUsecase) CompleteAttempt(ctx context.Context, input *input.Compl
 			return err
 		}
 
-		if attempt.Status == domain.StatusCompleted {
-			return errors.Wrap(domain.ErrFailedPrecondition, "試験は既に完了しています")
+		if attempt.IsFinished() {
+			return errors.Wrap(domain.ErrFailedPrecondition, "試験は既に終了しています")
 		}
 
-		questions, err := u.qRepo.FindByExamSetID(txCtx, attempt.ExamSetID)
-		if err != nil {
-			return err
+		// 中断中は回答を保存できないため、送信された回答を採点に含める場合は再開を必須とする
+		if attempt.Status == domain.StatusPaused && len(input.Answers) > 0 {
+			return errors.Wrap(domain.ErrFailedPrecondition, "中断中の受験は再開してから回答を送信してください")
 		}
 
-		qMap := lo.KeyBy(questions, func(q domain.Question) string {
-			return q.ID
-		})
-
-		score := 0
-		domainCorrect := make(map[string]int)
-		domainTotal := make(map[string]int)
+		// 受験開始後にサブスクリプションが失効した場合も考慮し、完了時に再度権限を確認する
+		if err := u.policy.AuthorizeExam(txCtx, input.UserID, attempt.ExamID); err != nil {
+			return err
+		}
 
-		lo.ForEach(lo.Entries(input.Answers), func(entry lo.Entry[string, []string], _ int) {
-			q, ok := qMap[entry.Key]
-			if !ok {
-				return // continue
-			}
-			domainTotal[q.Domain]++
-			if isCorrect(entry.Value, q.CorrectAnswers) {
-				score++
-				domainCorrect[q.Domain]++
+		if attempt.IsExpired(now) {
+			// 制限時間を過ぎてから送信された回答は採点に含めない
+			if err := u.finalize(txCtx, attempt, *attempt.Deadline); err != nil {
+				return err
 			}
-		})
+			completedAttempt = attempt
+			return nil
+		}
+
+		if err := u.validateSubmission(txCtx, attempt, input.Answers, nil, nil); err != nil {
+			return err
+		}
 
-		now := time.Now()
 		// UpdateAttempt と同様に、回答をマージする
 		if attempt.Answers == nil {
 			attempt.Answers = make(map[string][]string)
@@ -144,57 +297,369 @@ func (u *attemptUsecase) CompleteAttempt(ctx context.Context, input *input.Compl
 		for k, v := range input.Answers {
 			attempt.Answers[k] = v
 		}
-		attempt.Status = domain.StatusCompleted
-		attempt.Score = score
-		attempt.CompletedAt = &now
-		attempt.UpdatedAt = now
+		if err := u.finalize(txCtx, attempt, now); err != nil {
+			return err
+		}
+
+		completedAttempt = attempt
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output.NewAttemptOutput(completedAttempt, now), nil
+}
+
+// PauseAttempt は進行中の受験を中断します。中断中は制限時間が進みません。
+func (u *attemptUsecase) PauseAttempt(ctx context.Context, input *input.TransitionAttempt) (*output.Attempt, error) {
+	return u.transition(ctx, input, (*domain.Attempt).Pause)
+}
+
+// ResumeAttempt は中断中の受験を再開します。
+func (u *attemptUsecase) ResumeAttempt(ctx context.Context, input *input.TransitionAttempt) (*output.Attempt, error) {
+	return u.transition(ctx, input, (*domain.Attempt).Resume)
+}
+
+// AbandonAttempt は未完了の受験を放棄します。放棄した受験は採点されず、成績にも反映されません。
+func (u *attemptUsecase) AbandonAttempt(ctx context.Context, input *input.TransitionAttempt) (*output.Attempt, error) {
+	return u.transition(ctx, input, (*domain.Attempt).Abandon)
+}
+
+// transition は受験を取得して状態遷移 apply を適用し、保存します。
+func (u *attemptUsecase) transition(ctx context.Context, input *input.TransitionAttempt, apply func(*domain.Attempt, time.Time) error) (*output.Attempt, error) {
+	attempt, err := u.aRepo.Find(ctx, input.AttemptID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	expired, err := u.expireIfOverdue(ctx, attempt)
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		return nil, errors.Wrap(domain.ErrFailedPrecondition, "制限時間を過ぎたため受験は終了しています")
+	}
+
+	now := time.Now()
+	if err := apply(attempt, now); err != nil {
+		return nil, err
+	}
+	if err := u.aRepo.Save(ctx, *attempt); err != nil {
+		return nil, err
+	}
+
+	return output.NewAttemptOutput(attempt, now), nil
+}
+
+// expireIfOverdue は制限時間を過ぎた受験を、保存済みの回答で採点して完了させます。
+// クライアント側のタイマーを迂回されても、サーバー側で期限を強制するために使用します。
+func (u *attemptUsecase) expireIfOverdue(ctx context.Context, attempt *domain.Attempt) (bool, error) {
+	if !attempt.IsExpired(time.Now()) {
+		return false, nil
+	}
+
+	err := u.txRepo.Run(ctx, func(txCtx context.Context) error {
+		latest, err := u.aRepo.Find(txCtx, attempt.ID, attempt.UserID)
+		if err != nil {
+			return err
+		}
+		if latest.IsFinished() {
+			// 別のリクエストで既に終了している
+			*attempt = *latest
+			return nil
+		}
+		if err := u.finalize(txCtx, latest, *latest.Deadline); err != nil {
+			return err
+		}
+		*attempt = *latest
+		return nil
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "制限時間を過ぎた受験の終了処理に失敗しました")
+	}
+	return true, nil
+}
+
+// validateSubmission は受験対象の問題に対して回答・回答時間・見直しフラグを検証します。
+// flagged が nil の場合は見直しフラグを検証しません。
+func (u *attemptUsecase) validateSubmission(ctx context.Context, attempt *domain.Attempt, answers map[string][]string, timeSpent map[string]int, flagged *[]string) error {
+	if len(answers) == 0 && len(timeSpent) == 0 && (flagged == nil || len(*flagged) == 0) {
+		return nil
+	}
+	questions, err := findAttemptQuestions(ctx, u.qRepo, attempt)
+	if err != nil {
+		return err
+	}
+
+	verr := &domain.ValidationError{}
+	verr.Merge(domain.ValidateAnswers(questions, answers))
+	verr.Merge(domain.ValidateTimeSpent(questions, timeSpent))
+	if flagged != nil {
+		verr.Merge(domain.ValidateFlaggedQuestionIDs(questions, *flagged))
+	}
+	return verr.ErrOrNil()
+}
 
-		stats, err := u.sRepo.Find(txCtx, input.UserID, attempt.ExamID)
+// finalize は保存済みの回答を採点して受験を完了状態にし、ユーザーの累積成績に反映します。
+// トランザクション内で呼び出す必要があります。
+func (u *attemptUsecase) finalize(txCtx context.Context, attempt *domain.Attempt, completedAt time.Time) error {
+	questions, err := findAttemptQuestions(txCtx, u.qRepo, attempt)
+	if err != nil {
+		return err
+	}
+
+	if err := attempt.Complete(completedAt); err != nil {
+		return err
+	}
+	if err := scoreAttempt(attempt, questions); err != nil {
+		return err
+	}
+
+	stats, err := u.sRepo.Find(txCtx, attempt.UserID, attempt.ExamID)
+	if err != nil {
+		return err
+	}
+	if stats == nil {
+		stats, err = domain.NewUserExamStats(attempt.UserID, attempt.ExamID)
 		if err != nil {
 			return err
 		}
-		if stats == nil {
-			stats, err = domain.NewUserExamStats(input.UserID, attempt.ExamID)
+	}
+	stats.AddAttempt(attempt)
+
+	if err := u.aRepo.Save(txCtx, *attempt); err != nil {
+		return err
+	}
+	if err := u.sRepo.Save(txCtx, *stats); err != nil {
+		return err
+	}
+	if err := u.scheduleReviews(txCtx, attempt, questions, completedAt); err != nil {
+		return err
+	}
+
+	// 練習形式は受験中に正解を閲覧できるため、項目分析の集計には含めない
+	if attempt.Mode != domain.ModeExam {
+		return nil
+	}
+	return u.qsRepo.Record(txCtx, questionResponses(attempt, questions))
+}
+
+// scheduleReviews は採点済みの受験の結果を間隔反復学習のスケジュールに反映します。
+// 復習対象に登録済みの問題は正誤に応じて箱を移動し、未登録の問題は不正解(未回答を含む)の場合のみ登録します。
+func (u *attemptUsecase) scheduleReviews(txCtx context.Context, attempt *domain.Attempt, questions []domain.Question, now time.Time) error {
+	existing, err := u.rRepo.FindByQuestionIDs(txCtx, attempt.UserID, util.Map(questions, func(q domain.Question) string { return q.ID }))
+	if err != nil {
+		return err
+	}
+	itemsByID := lo.KeyBy(existing, func(item domain.ReviewItem) string { return item.QuestionID })
+
+	var items []domain.ReviewItem
+	for _, q := range questions {
+		selected := attempt.Answers[q.ID]
+		correct := len(selected) > 0 && isCorrect(selected, q.CorrectAnswers)
+
+		item, ok := itemsByID[q.ID]
+		if !ok {
+			if correct {
+				continue
+			}
+			created, err := domain.NewReviewItem(attempt.UserID, q, now)
 			if err != nil {
 				return err
 			}
+			items = append(items, *created)
+			continue
 		}
+		item.RecordOutcome(correct, now)
+		items = append(items, item)
+	}
+
+	return u.rRepo.SaveAll(txCtx, items)
+}
 
-		stats.TotalAttempts++
-		stats.TotalScore += score
-		stats.TotalQuestionsAnswered += attempt.TotalQuestions
-		stats.LastTakenAt = now
+// questionResponses は採点済みの受験から、項目分析に加算する問題ごとの回答結果を生成します。
+func questionResponses(attempt *domain.Attempt, questions []domain.Question) []domain.QuestionResponse {
+	return util.Map(questions, func(q domain.Question) domain.QuestionResponse {
+		selected := attempt.Answers[q.ID]
+		correct := isCorrect(selected, q.CorrectAnswers) && len(selected) > 0
 
-		for dName, total := range domainTotal {
-			correct := domainCorrect[dName]
-			dScore, ok := stats.DomainStats[dName]
-			if !ok {
-				dScore = domain.DomainScore{DomainName: dName}
-			}
-			dScore.TotalCount += total
-			dScore.CorrectCount += correct
-			if dScore.TotalCount > 0 {
-				dScore.AccuracyRate = int(math.Round(float64(dScore.CorrectCount) / float64(dScore.TotalCount) * 100))
-			}
-			stats.DomainStats[dName] = dScore
+		rest := attempt.CorrectCount
+		if correct {
+			rest--
+		}
+		restScore := 0.0
+		if len(questions) > 1 {
+			restScore = float64(rest) / float64(len(questions)-1)
 		}
 
-		if err := u.aRepo.Save(txCtx, *attempt); err != nil {
-			return err
+		examSetID := attempt.ExamSetID
+		if attempt.IsGenerated() {
+			examSetID = q.ExamSetID
 		}
-		if err := u.sRepo.Save(txCtx, *stats); err != nil {
-			return err
+
+		return domain.QuestionResponse{
+			QuestionID:      q.ID,
+			ExamID:          attempt.ExamID,
+			ExamSetID:       examSetID,
+			Revision:        q.CurrentRevision(),
+			SelectedOptions: lo.Uniq(selected),
+			Correct:         correct,
+			RestScore:       restScore,
 		}
+	})
+}
 
-		completedAttempt = attempt
-		return nil
+// scoreAttempt は保存済みの回答を questions に対して採点し、採点結果を attempt に記録します。
+// questions の全問題を採点対象とし、未回答の問題は不正解として分野ごとの集計に含めます。
+func scoreAttempt(attempt *domain.Attempt, questions []domain.Question) error {
+	scorer, err := NewScorer(attempt.ScoringStrategy)
+	if err != nil {
+		return err
+	}
+
+	score := 0
+	answered := 0
+	points := 0.0
+	domainScores := make(map[string]domain.DomainScore)
+	flagSummary := &domain.FlagSummary{}
+
+	for _, q := range questions {
+		selected := attempt.Answers[q.ID]
+		result := domain.DomainScore{DomainName: q.Domain, TotalCount: 1, TimeSpentSeconds: attempt.TimeSpent[q.ID]}
+		if len(selected) > 0 {
+			answered++
+			result.Points = scorer.Score(q, selected)
+			points += result.Points
+			if isCorrect(selected, q.CorrectAnswers) {
+				score++
+				result.CorrectCount = 1
+			}
+		}
+		domainScores[q.Domain] = domainScores[q.Domain].Add(result)
+		flagSummary.Add(attempt.IsFlagged(q.ID), result.CorrectCount == 1)
+	}
+
+	attempt.Score = score
+	attempt.CorrectCount = score
+	attempt.AnsweredCount = answered
+	attempt.UnansweredCount = len(questions) - answered
+	attempt.Points = points
+	domainNames := lo.Keys(domainScores)
+	slices.Sort(domainNames)
+	attempt.DomainScores = util.Map(domainNames, func(name string) domain.DomainScore {
+		return domainScores[name]
+	})
+	attempt.FlagSummary = flagSummary
+	attempt.ApplyVerdict()
+	return nil
+}
+
+// ListAttempts はユーザーの受験履歴をカーソル方式でページングして返します。
+func (u *attemptUsecase) ListAttempts(ctx context.Context, input *input.ListAttempts) (*output.AttemptList, error) {
+	attempts, nextCursor, err := u.aRepo.FindByUser(ctx, repository.AttemptQuery{
+		UserID:    input.UserID,
+		ExamID:    input.ExamID,
+		ExamSetID: input.ExamSetID,
+		Statuses:  input.Statuses,
+		OrderBy:   input.OrderBy,
+		Limit:     input.Limit,
+		Cursor:    input.Cursor,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.NewAttemptList(attempts, nextCursor, time.Now()), nil
+}
+
+// GetAttemptQuestions は受験対象の問題を、正解・解説を除いて出題順に返します。
+// 個別に生成したセットは試験セットの問題一覧から取得できないため、受験画面はこちらを使用します。
+func (u *attemptUsecase) GetAttemptQuestions(ctx context.Context, input *input.GetAttempt) ([]output.Question, error) {
+	attempt, err := u.aRepo.Find(ctx, input.AttemptID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.policy.AuthorizeExam(ctx, input.UserID, attempt.ExamID); err != nil {
+		return nil, err
+	}
+
+	questions, err := findAttemptQuestions(ctx, u.qRepo, attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.NewQuestions(questions), nil
+}
+
+// GetAttemptAnswers は受験対象の問題を正解・解説付きで返します。
+// 受験完了後、または練習モードの受験でのみ取得できます。
+func (u *attemptUsecase) GetAttemptAnswers(ctx context.Context, input *input.GetAttempt) ([]output.QuestionWithAnswer, error) {
+	attempt, err := u.aRepo.Find(ctx, input.AttemptID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
 
+	if !attempt.CanRevealAnswers() {
+		return nil, errors.Wrap(domain.ErrFailedPrecondition, "試験の完了前は正解と解説を閲覧できません")
+	}
+
+	if err := u.policy.AuthorizeExam(ctx, input.UserID, attempt.ExamID); err != nil {
+		return nil, err
+	}
+
+	questions, err := findAttemptQuestions(ctx, u.qRepo, attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.NewQuestionsWithAnswers(questions), nil
+}
+
+// GetAttemptReview は完了した受験について、1問ごとの回答・正解・解説を突き合わせた結果を返します。
+func (u *attemptUsecase) GetAttemptReview(ctx context.Context, input *input.GetAttempt) (*output.AttemptReview, error) {
+	attempt, err := u.aRepo.Find(ctx, input.AttemptID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if attempt.Status != domain.StatusCompleted {
+		return nil, errors.Wrap(domain.ErrFailedPrecondition, "振り返りは試験の完了後に閲覧できます")
+	}
+
+	if err := u.policy.AuthorizeExam(ctx, input.UserID, attempt.ExamID); err != nil {
+		return nil, err
+	}
+
+	questions, err := findAttemptQuestions(ctx, u.qRepo, attempt)
 	if err != nil {
 		return nil, err
 	}
 
-	return output.NewAttemptOutput(completedAttempt), nil
+	scorer, err := NewScorer(attempt.ScoringStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := util.Map(questions, func(q domain.Question) output.QuestionReview {
+		selected := attempt.Answers[q.ID]
+		return output.NewQuestionReview(q, selected, gradeQuestion(q, selected), scorer.Score(q, selected), attempt.TimeSpent[q.ID], attempt.IsFlagged(q.ID))
+	})
+
+	return output.NewAttemptReview(output.NewAttemptOutput(attempt, time.Now()), reviews), nil
+}
+
+// gradeQuestion は1問分の回答を正解・不正解・未回答に分類します。
+func gradeQuestion(q domain.Question, selected []string) output.QuestionResult {
+	if len(selected) == 0 {
+		return output.ResultUnanswered
+	}
+	if isCorrect(selected, q.CorrectAnswers) {
+		return output.ResultCorrect
+	}
+	return output.ResultIncorrect
 }
 
 // isCorrect は、ユーザーの回答と正解が順序を問わず一致するかどうかを判定します。
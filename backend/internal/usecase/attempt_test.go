@@ -9,7 +9,9 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
 	"nearline/backend/internal/usecase/input"
+	"nearline/backend/internal/usecase/output"
 )
 
 // MockAttemptRepository is a mock implementation of AttemptRepository
@@ -30,6 +32,14 @@ func (m *MockAttemptRepository) Find(ctx context.Context, id, userID string) (*d
 	return args.Get(0).(*domain.Attempt), args.Error(1)
 }
 
+func (m *MockAttemptRepository) FindByUser(ctx context.Context, query repository.AttemptQuery) ([]domain.Attempt, string, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Attempt), args.String(1), args.Error(2)
+}
+
 // MockQuestionRepository is a mock implementation of QuestionRepository
 type MockQuestionRepository struct {
 	mock.Mock
@@ -40,14 +50,210 @@ func (m *MockQuestionRepository) BulkCreate(ctx context.Context, questions []dom
 	return args.Error(0)
 }
 
-func (m *MockQuestionRepository) FindByExamSetID(ctx context.Context, examSetID string) ([]domain.Question, error) {
-	args := m.Called(ctx, examSetID)
+func (m *MockQuestionRepository) FindByExamSetID(ctx context.Context, examID, examSetID string) ([]domain.Question, error) {
+	args := m.Called(ctx, examID, examSetID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.Question), args.Error(1)
 }
 
+func (m *MockQuestionRepository) FindByExamID(ctx context.Context, examID string) ([]domain.Question, error) {
+	args := m.Called(ctx, examID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Question), args.Error(1)
+}
+
+func (m *MockQuestionRepository) FindByIDs(ctx context.Context, ids []string) ([]domain.Question, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Question), args.Error(1)
+}
+
+func (m *MockQuestionRepository) Find(ctx context.Context, id string) (*domain.Question, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Question), args.Error(1)
+}
+
+func (m *MockQuestionRepository) Save(ctx context.Context, question domain.Question) error {
+	args := m.Called(ctx, question)
+	return args.Error(0)
+}
+
+func (m *MockQuestionRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockQuestionRepository) SaveRevision(ctx context.Context, revision domain.QuestionRevision) error {
+	args := m.Called(ctx, revision)
+	return args.Error(0)
+}
+
+func (m *MockQuestionRepository) FindRevision(ctx context.Context, questionID string, revision int) (*domain.QuestionRevision, error) {
+	args := m.Called(ctx, questionID, revision)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.QuestionRevision), args.Error(1)
+}
+
+func (m *MockQuestionRepository) FindRevisions(ctx context.Context, questionID string) ([]domain.QuestionRevision, error) {
+	args := m.Called(ctx, questionID)
+	return args.Get(0).([]domain.QuestionRevision), args.Error(1)
+}
+
+// MockQuestionStatsRepository is a mock implementation of QuestionStatsRepository
+type MockQuestionStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuestionStatsRepository) Record(ctx context.Context, responses []domain.QuestionResponse) error {
+	args := m.Called(ctx, responses)
+	return args.Error(0)
+}
+
+func (m *MockQuestionStatsRepository) FindByExamID(ctx context.Context, examID string) ([]domain.QuestionStats, error) {
+	args := m.Called(ctx, examID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.QuestionStats), args.Error(1)
+}
+
+// newMockQuestionStatsRepository は回答統計の記録を常に成功させるモックを返します。
+func newMockQuestionStatsRepository() *MockQuestionStatsRepository {
+	m := new(MockQuestionStatsRepository)
+	m.On("Record", mock.Anything, mock.Anything).Return(nil)
+	return m
+}
+
+// MockReviewItemRepository is a mock implementation of ReviewItemRepository
+type MockReviewItemRepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewItemRepository) Save(ctx context.Context, item domain.ReviewItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockReviewItemRepository) SaveAll(ctx context.Context, items []domain.ReviewItem) error {
+	args := m.Called(ctx, items)
+	return args.Error(0)
+}
+
+func (m *MockReviewItemRepository) Find(ctx context.Context, userID, questionID string) (*domain.ReviewItem, error) {
+	args := m.Called(ctx, userID, questionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewItem), args.Error(1)
+}
+
+func (m *MockReviewItemRepository) FindByQuestionIDs(ctx context.Context, userID string, questionIDs []string) ([]domain.ReviewItem, error) {
+	args := m.Called(ctx, userID, questionIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReviewItem), args.Error(1)
+}
+
+func (m *MockReviewItemRepository) FindDue(ctx context.Context, userID, examID string, now time.Time, limit int) ([]domain.ReviewItem, error) {
+	args := m.Called(ctx, userID, examID, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReviewItem), args.Error(1)
+}
+
+// newMockReviewItemRepository は復習項目が未登録で、保存を常に成功させるモックを返します。
+func newMockReviewItemRepository() *MockReviewItemRepository {
+	m := new(MockReviewItemRepository)
+	m.On("FindByQuestionIDs", mock.Anything, mock.Anything, mock.Anything).Return([]domain.ReviewItem{}, nil)
+	m.On("SaveAll", mock.Anything, mock.Anything).Return(nil)
+	return m
+}
+
+// MockExamRepository is a mock implementation of ExamRepository
+type MockExamRepository struct {
+	mock.Mock
+}
+
+func (m *MockExamRepository) FindAll(ctx context.Context) ([]domain.Exam, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Exam), args.Error(1)
+}
+
+func (m *MockExamRepository) Find(ctx context.Context, id string) (*domain.Exam, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Exam), args.Error(1)
+}
+
+func (m *MockExamRepository) FindSets(ctx context.Context, examID string) ([]domain.ExamSet, error) {
+	args := m.Called(ctx, examID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ExamSet), args.Error(1)
+}
+
+func (m *MockExamRepository) FindSet(ctx context.Context, examID, examSetID string) (*domain.ExamSet, error) {
+	args := m.Called(ctx, examID, examSetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ExamSet), args.Error(1)
+}
+
+func (m *MockExamRepository) Create(ctx context.Context, exam domain.Exam) error {
+	args := m.Called(ctx, exam)
+	return args.Error(0)
+}
+
+func (m *MockExamRepository) Save(ctx context.Context, exam domain.Exam) error {
+	args := m.Called(ctx, exam)
+	return args.Error(0)
+}
+
+func (m *MockExamRepository) ReserveCode(ctx context.Context, code, examID string) error {
+	args := m.Called(ctx, code, examID)
+	return args.Error(0)
+}
+
+func (m *MockExamRepository) ReleaseCode(ctx context.Context, code, examID string) error {
+	args := m.Called(ctx, code, examID)
+	return args.Error(0)
+}
+
+func (m *MockExamRepository) CreateSet(ctx context.Context, examSet domain.ExamSet) error {
+	args := m.Called(ctx, examSet)
+	return args.Error(0)
+}
+
+func (m *MockExamRepository) SaveSet(ctx context.Context, examSet domain.ExamSet) error {
+	args := m.Called(ctx, examSet)
+	return args.Error(0)
+}
+
+func (m *MockExamRepository) DeleteSet(ctx context.Context, examID, examSetID string) error {
+	args := m.Called(ctx, examID, examSetID)
+	return args.Error(0)
+}
+
 // MockUserStatsRepository is a mock implementation of UserStatsRepository
 type MockUserStatsRepository struct {
 	mock.Mock
@@ -66,6 +272,40 @@ func (m *MockUserStatsRepository) Find(ctx context.Context, userID, examID strin
 	return args.Get(0).(*domain.UserExamStats), args.Error(1)
 }
 
+// MockUserRepository is a mock implementation of UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Find(ctx context.Context, id string) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindAll(ctx context.Context) ([]domain.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
 // MockTransactionRepository is a mock implementation of TransactionRepository
 type MockTransactionRepository struct {
 	mock.Mock
@@ -82,8 +322,9 @@ func TestUpdateAttempt_MergesAnswers(t *testing.T) {
 	mockQuestionRepo := new(MockQuestionRepository)
 	mockStatsRepo := new(MockUserStatsRepository)
 	mockTxRepo := new(MockTransactionRepository)
+	mockUserRepo := new(MockUserRepository)
 
-	usecase := NewAttemptUsecase(mockQuestionRepo, mockAttemptRepo, mockStatsRepo, mockTxRepo)
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, mockStatsRepo, newMockQuestionStatsRepository(), newMockReviewItemRepository(), mockTxRepo, NewAccessPolicy(mockUserRepo))
 
 	ctx := context.Background()
 	userID := "user123"
@@ -111,6 +352,14 @@ func TestUpdateAttempt_MergesAnswers(t *testing.T) {
 	// Mock Find to return the existing attempt
 	mockAttemptRepo.On("Find", ctx, attemptID, userID).Return(existingAttempt, nil)
 
+	// Mock FindByExamSetID to return the questions used for answer validation
+	options := []domain.AnswerOption{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+	mockQuestionRepo.On("FindByExamSetID", ctx, examID, examSetID).Return([]domain.Question{
+		{ID: "q1", ExamSetID: examSetID, Options: options},
+		{ID: "q2", ExamSetID: examSetID, Options: options},
+		{ID: "q3", ExamSetID: examSetID, Options: options},
+	}, nil)
+
 	// Mock Save to capture the updated attempt
 	var capturedAttempt domain.Attempt
 	mockAttemptRepo.On("Save", ctx, mock.MatchedBy(func(a domain.Attempt) bool {
@@ -204,4 +453,737 @@ func TestIsCorrect(t *testing.T) {
 			assert.Equal(t, tt.expected, isCorrect(tt.userAns, tt.correctAns), tt.name)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestScorer(t *testing.T) {
+	single := domain.Question{QuestionType: "multiple-choice", CorrectAnswers: []string{"a"}}
+	multi := domain.Question{QuestionType: "multi-select", CorrectAnswers: []string{"a", "b"}}
+
+	tests := []struct {
+		name     string
+		strategy domain.ScoringStrategy
+		question domain.Question
+		selected []string
+		expected float64
+	}{
+		{name: "未設定は完全一致のみ", strategy: "", question: multi, selected: []string{"a"}, expected: 0},
+		{name: "完全一致方式で正解", strategy: domain.ScoringAllOrNothing, question: multi, selected: []string{"b", "a"}, expected: 1},
+		{name: "部分点方式で半分正解", strategy: domain.ScoringPartialCredit, question: multi, selected: []string{"a"}, expected: 0.5},
+		{name: "部分点方式で誤答を含む場合は減点", strategy: domain.ScoringPartialCredit, question: multi, selected: []string{"a", "c"}, expected: 0},
+		{name: "部分点方式で全選択", strategy: domain.ScoringPartialCredit, question: multi, selected: []string{"a", "b", "c", "d"}, expected: 0},
+		{name: "部分点方式でも単一選択は完全一致のみ", strategy: domain.ScoringPartialCredit, question: single, selected: []string{"b"}, expected: 0},
+		{name: "減点方式で正解", strategy: domain.ScoringNegativeMarking, question: single, selected: []string{"a"}, expected: 1},
+		{name: "減点方式で不正解", strategy: domain.ScoringNegativeMarking, question: single, selected: []string{"b"}, expected: -domain.NegativeMarkingPenalty},
+		{name: "減点方式で未回答", strategy: domain.ScoringNegativeMarking, question: single, selected: nil, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer, err := NewScorer(tt.strategy)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, scorer.Score(tt.question, tt.selected), 1e-9)
+		})
+	}
+
+	_, err := NewScorer("unknown")
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestCompleteAttempt_RecordsFractionalPoints(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockStatsRepo := new(MockUserStatsRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, mockStatsRepo, newMockQuestionStatsRepository(), newMockReviewItemRepository(), mockTxRepo, NewAccessPolicy(mockUserRepo))
+
+	ctx := context.Background()
+	userID := "user-1"
+	attempt, _ := domain.NewAttempt("attempt-1", userID, "cloud-digital-leader", "set-1", domain.ModeExam, 2, time.Now())
+	attempt.ScoringStrategy = domain.ScoringPartialCredit
+
+	questions := []domain.Question{
+		{ID: "q1", Domain: "Compute", QuestionType: "multi-select", Options: []domain.AnswerOption{{ID: "a"}, {ID: "b"}}, CorrectAnswers: []string{"a", "b"}},
+		{ID: "q2", Domain: "Compute", QuestionType: "multiple-choice", Options: []domain.AnswerOption{{ID: "a"}, {ID: "b"}}, CorrectAnswers: []string{"a"}},
+	}
+	answers := map[string][]string{"q1": {"a"}, "q2": {"a"}}
+
+	mockUserRepo.On("Find", ctx, userID).Return(&domain.User{ID: userID, Role: domain.RoleFree}, nil)
+	mockAttemptRepo.On("Find", ctx, "attempt-1", userID).Return(attempt, nil)
+	mockQuestionRepo.On("FindByExamSetID", ctx, "cloud-digital-leader", "set-1").Return(questions, nil)
+	mockStatsRepo.On("Find", ctx, userID, "cloud-digital-leader").Return(nil, nil)
+	mockAttemptRepo.On("Save", ctx, mock.AnythingOfType("domain.Attempt")).Return(nil)
+	mockStatsRepo.On("Save", ctx, mock.MatchedBy(func(s domain.UserExamStats) bool {
+		d := s.DomainStats["Compute"]
+		return s.TotalScore == 1 && s.TotalPoints == 1.5 && d.Points == 1.5 && d.AccuracyRate == 50 && d.ScoreRate == 75
+	})).Return(nil)
+
+	in, err := input.NewCompleteAttempt(userID, "attempt-1", answers)
+	assert.NoError(t, err)
+
+	out, err := usecase.CompleteAttempt(ctx, in)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, out.Score)
+	assert.InDelta(t, 1.5, out.Points, 1e-9)
+	assert.Equal(t, domain.ScoringPartialCredit, out.ScoringStrategy)
+	mockStatsRepo.AssertExpectations(t)
+}
+
+func TestCompleteAttempt_WeightedVerdict(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockStatsRepo := new(MockUserStatsRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, mockStatsRepo, newMockQuestionStatsRepository(), newMockReviewItemRepository(), mockTxRepo, NewAccessPolicy(mockUserRepo))
+
+	ctx := context.Background()
+	userID := "user-1"
+	examID := "cloud-digital-leader"
+	attempt, _ := domain.NewAttempt("attempt-1", userID, examID, "set-1", domain.ModeExam, 2, time.Now())
+	attempt.PassingScore = 70
+	attempt.DomainWeights = map[string]float64{"Compute": 3, "Security": 1}
+
+	questions := []domain.Question{
+		{ID: "q1", Domain: "Compute", Options: []domain.AnswerOption{{ID: "a"}, {ID: "b"}}, CorrectAnswers: []string{"a"}},
+		{ID: "q2", Domain: "Security", Options: []domain.AnswerOption{{ID: "a"}, {ID: "b"}}, CorrectAnswers: []string{"a"}},
+	}
+	answers := map[string][]string{"q1": {"a"}, "q2": {"b"}}
+
+	prevStats, _ := domain.NewUserExamStats(userID, examID)
+	prevStats.TotalAttempts = 1
+	prevStats.BestScore = 80
+
+	mockUserRepo.On("Find", ctx, userID).Return(&domain.User{ID: userID, Role: domain.RoleFree}, nil)
+	mockAttemptRepo.On("Find", ctx, "attempt-1", userID).Return(attempt, nil)
+	mockQuestionRepo.On("FindByExamSetID", ctx, "cloud-digital-leader", "set-1").Return(questions, nil)
+	mockStatsRepo.On("Find", ctx, userID, examID).Return(prevStats, nil)
+	mockAttemptRepo.On("Save", ctx, mock.AnythingOfType("domain.Attempt")).Return(nil)
+	mockStatsRepo.On("Save", ctx, mock.MatchedBy(func(s domain.UserExamStats) bool {
+		return s.PassCount == 1 && s.BestScore == 80
+	})).Return(nil)
+
+	in, err := input.NewCompleteAttempt(userID, "attempt-1", answers)
+	assert.NoError(t, err)
+
+	out, err := usecase.CompleteAttempt(ctx, in)
+	assert.NoError(t, err)
+	assert.Equal(t, 50.0, out.Percentage)
+	assert.Equal(t, 75.0, out.ScaledScore)
+	assert.True(t, out.Passed)
+	mockStatsRepo.AssertExpectations(t)
+}
+
+func TestApplyVerdict_PartialDomainWeights(t *testing.T) {
+	newAttempt := func(weights map[string]float64) *domain.Attempt {
+		return &domain.Attempt{
+			PassingScore:  70,
+			DomainWeights: weights,
+			DomainScores: []domain.DomainScore{
+				{DomainName: "Compute", TotalCount: 3, Points: 3},
+				{DomainName: "Security", TotalCount: 1, Points: 0},
+			},
+		}
+	}
+
+	t.Run("出題したすべての分野に配点比率がある場合は加重平均する", func(t *testing.T) {
+		attempt := newAttempt(map[string]float64{"Compute": 1, "Security": 1})
+		attempt.ApplyVerdict()
+
+		assert.Equal(t, 75.0, attempt.Percentage)
+		assert.Equal(t, 50.0, attempt.ScaledScore)
+		assert.False(t, attempt.Passed)
+	})
+
+	t.Run("配点比率のない分野がある場合は得点率を使用する", func(t *testing.T) {
+		// 分野名の誤りで Security に配点比率がない
+		attempt := newAttempt(map[string]float64{"Compute": 1, "Secruity": 1})
+		attempt.ApplyVerdict()
+
+		assert.Equal(t, 75.0, attempt.ScaledScore, "Security の結果を除外して100点にしない")
+		assert.True(t, attempt.Passed)
+	})
+}
+
+func TestUpdateAttempt_RejectsInvalidAnswers(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, new(MockUserStatsRepository), newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+
+	ctx := context.Background()
+	attempt, _ := domain.NewAttempt("attempt-1", "user-1", "cloud-digital-leader", "set-1", domain.ModeExam, 2, time.Now())
+	options := []domain.AnswerOption{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+
+	mockAttemptRepo.On("Find", ctx, "attempt-1", "user-1").Return(attempt, nil)
+	mockQuestionRepo.On("FindByExamSetID", ctx, "cloud-digital-leader", "set-1").Return([]domain.Question{
+		{ID: "q1", QuestionType: domain.QuestionTypeMultipleChoice, Options: options},
+		{ID: "q2", QuestionType: domain.QuestionTypeMultiSelect, Options: options},
+	}, nil)
+
+	err := usecase.UpdateAttempt(ctx, "user-1", "attempt-1", input.UpdateAttemptRequest{
+		Answers: map[string][]string{
+			"q1": {"a", "b"},
+			"q2": {"a", "a", "z"},
+			"q9": {"a"},
+			"q3": {},
+		},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	var verr *domain.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Equal(t, []domain.FieldError{
+		{Field: "answers.q1", Message: "単一選択問題では選択肢を1つだけ選んでください"},
+		{Field: "answers.q2", Message: "存在しない選択肢です: z"},
+		{Field: "answers.q2", Message: "同じ選択肢が重複しています"},
+		{Field: "answers.q3", Message: "この試験セットに存在しない問題です"},
+		{Field: "answers.q9", Message: "この試験セットに存在しない問題です"},
+	}, verr.Fields)
+	mockAttemptRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCompleteAttempt_ScoresMergedAnswerSheet(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockStatsRepo := new(MockUserStatsRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, mockStatsRepo, newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+	ctx := context.Background()
+	userID := "user-1"
+	examID := "cloud-digital-leader"
+	attempt, _ := domain.NewAttempt("attempt-1", userID, examID, "set-1", domain.ModeExam, 3, time.Now())
+	// PUT で保存済みの回答
+	attempt.Answers = map[string][]string{"q1": {"a"}}
+
+	options := []domain.AnswerOption{{ID: "a"}, {ID: "b"}}
+	questions := []domain.Question{
+		{ID: "q1", Domain: "Compute", Options: options, CorrectAnswers: []string{"a"}},
+		{ID: "q2", Domain: "Compute", Options: options, CorrectAnswers: []string{"a"}},
+		{ID: "q3", Domain: "Security", Options: options, CorrectAnswers: []string{"a"}},
+	}
+
+	mockUserRepo.On("Find", ctx, userID).Return(&domain.User{ID: userID, Role: domain.RoleFree}, nil)
+	mockAttemptRepo.On("Find", ctx, "attempt-1", userID).Return(attempt, nil)
+	mockQuestionRepo.On("FindByExamSetID", ctx, "cloud-digital-leader", "set-1").Return(questions, nil)
+	mockStatsRepo.On("Find", ctx, userID, examID).Return(nil, nil)
+	mockAttemptRepo.On("Save", ctx, mock.AnythingOfType("domain.Attempt")).Return(nil)
+
+	var savedStats domain.UserExamStats
+	mockStatsRepo.On("Save", ctx, mock.MatchedBy(func(s domain.UserExamStats) bool {
+		savedStats = s
+		return true
+	})).Return(nil)
+
+	in, err := input.NewCompleteAttempt(userID, "attempt-1", map[string][]string{"q2": {"a"}})
+	assert.NoError(t, err)
+
+	out, err := usecase.CompleteAttempt(ctx, in)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, out.Score)
+	assert.Equal(t, 2, out.CorrectCount)
+	assert.Equal(t, 2, out.AnsweredCount)
+	assert.Equal(t, 1, out.UnansweredCount)
+	assert.Equal(t, []domain.DomainScore{
+		{DomainName: "Compute", CorrectCount: 2, TotalCount: 2, AccuracyRate: 100, Points: 2, ScoreRate: 100},
+		{DomainName: "Security", CorrectCount: 0, TotalCount: 1, AccuracyRate: 0, Points: 0, ScoreRate: 0},
+	}, out.DomainScores)
+
+	assert.Equal(t, 2, savedStats.TotalQuestionsAnswered)
+	assert.Equal(t, domain.DomainScore{DomainName: "Compute", CorrectCount: 2, TotalCount: 2, AccuracyRate: 100, Points: 2, ScoreRate: 100}, savedStats.DomainStats["Compute"])
+	assert.Equal(t, domain.DomainScore{DomainName: "Security", CorrectCount: 0, TotalCount: 1, AccuracyRate: 0, Points: 0, ScoreRate: 0}, savedStats.DomainStats["Security"])
+}
+
+func TestStartAttempt_DeniesFreeUserOnPaidExam(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockStatsRepo := new(MockUserStatsRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, mockStatsRepo, newMockQuestionStatsRepository(), newMockReviewItemRepository(), mockTxRepo, NewAccessPolicy(mockUserRepo))
+
+	ctx := context.Background()
+	user := domain.NewUser("user123", "user@example.com", domain.ProviderGoogle)
+	mockUserRepo.On("Find", ctx, user.ID).Return(user, nil)
+
+	in, _ := input.NewCreateAttempt(user.ID, input.CreateAttemptRequest{
+		ExamID:    "professional-cloud-developer",
+		ExamSetID: "practice_exam_1",
+	})
+	_, err := usecase.StartAttempt(ctx, in)
+
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	var accessErr *domain.AccessDeniedError
+	assert.ErrorAs(t, err, &accessErr)
+	assert.Equal(t, domain.ReasonProRequired, accessErr.Reason)
+	mockQuestionRepo.AssertNotCalled(t, "FindByExamSetID", mock.Anything, mock.Anything, mock.Anything)
+	mockAttemptRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestAccessPolicy_AuthorizeExam(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     domain.UserRole
+		sub      domain.SubscriptionStatus
+		examID   string
+		expected domain.AccessDeniedReason // 空文字の場合はアクセス許可
+	}{
+		{name: "無料ユーザーはCDLを受験できる", role: domain.RoleFree, sub: domain.SubActive, examID: "cloud-digital-leader"},
+		{name: "無料ユーザーはCDL以外を受験できない", role: domain.RoleFree, sub: domain.SubActive, examID: "professional-cloud-developer", expected: domain.ReasonProRequired},
+		{name: "有効なProユーザーは全て受験できる", role: domain.RolePro, sub: domain.SubActive, examID: "professional-cloud-developer"},
+		{name: "期限切れのProユーザーは無料ユーザーと同等", role: domain.RolePro, sub: domain.SubExpired, examID: "professional-cloud-developer", expected: domain.ReasonSubscriptionInactive},
+		{name: "解約済みのProユーザーもCDLは受験できる", role: domain.RolePro, sub: domain.SubCanceled, examID: "cloud-digital-leader"},
+		{name: "管理者は全て受験できる", role: domain.RoleAdmin, sub: domain.SubExpired, examID: "professional-cloud-developer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			user := domain.NewUser("user123", "user@example.com", domain.ProviderGoogle)
+			user.Role = tt.role
+			user.SubscriptionStatus = tt.sub
+
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("Find", ctx, user.ID).Return(user, nil)
+
+			err := NewAccessPolicy(mockUserRepo).AuthorizeExam(ctx, user.ID, tt.examID)
+			if tt.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var accessErr *domain.AccessDeniedError
+			assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+			assert.ErrorAs(t, err, &accessErr)
+			assert.Equal(t, tt.expected, accessErr.Reason)
+		})
+	}
+}
+
+func TestGetAttemptAnswers_HiddenUntilCompleted(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockStatsRepo := new(MockUserStatsRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, mockStatsRepo, newMockQuestionStatsRepository(), newMockReviewItemRepository(), mockTxRepo, NewAccessPolicy(mockUserRepo))
+
+	ctx := context.Background()
+	user := domain.NewUser("user123", "user@example.com", domain.ProviderGoogle)
+	mockUserRepo.On("Find", ctx, user.ID).Return(user, nil)
+
+	examAttempt, _ := domain.NewAttempt("attempt-exam", user.ID, "cloud-digital-leader", "set1", domain.ModeExam, 1, time.Now())
+	practiceAttempt, _ := domain.NewAttempt("attempt-practice", user.ID, "cloud-digital-leader", "set1", domain.ModePractice, 1, time.Now())
+	mockAttemptRepo.On("Find", ctx, examAttempt.ID, user.ID).Return(examAttempt, nil)
+	mockAttemptRepo.On("Find", ctx, practiceAttempt.ID, user.ID).Return(practiceAttempt, nil)
+
+	question := domain.Question{
+		ID:             "q1",
+		ExamID:         "cloud-digital-leader",
+		ExamSetID:      "set1",
+		Options:        []domain.AnswerOption{{ID: "1", Text: "A", Explanation: "正解です"}},
+		CorrectAnswers: []string{"1"},
+	}
+	mockQuestionRepo.On("FindByExamSetID", ctx, "cloud-digital-leader", "set1").Return([]domain.Question{question}, nil)
+
+	// 本番形式の受験中は正解を閲覧できない
+	in, _ := input.NewGetAttempt(user.ID, examAttempt.ID)
+	_, err := usecase.GetAttemptAnswers(ctx, in)
+	assert.ErrorIs(t, err, domain.ErrFailedPrecondition)
+
+	// 練習形式であれば受験中でも閲覧できる
+	in, _ = input.NewGetAttempt(user.ID, practiceAttempt.ID)
+	answers, err := usecase.GetAttemptAnswers(ctx, in)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, answers[0].CorrectAnswers)
+	assert.Equal(t, "正解です", answers[0].Options[0].Explanation)
+}
+
+func TestGetAttemptReview_ClassifiesEachQuestion(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockStatsRepo := new(MockUserStatsRepository)
+	mockTxRepo := new(MockTransactionRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, mockStatsRepo, newMockQuestionStatsRepository(), newMockReviewItemRepository(), mockTxRepo, NewAccessPolicy(mockUserRepo))
+
+	ctx := context.Background()
+	user := domain.NewUser("user123", "user@example.com", domain.ProviderGoogle)
+	mockUserRepo.On("Find", ctx, user.ID).Return(user, nil)
+
+	attempt, _ := domain.NewAttempt("attempt123", user.ID, "cloud-digital-leader", "set1", domain.ModeExam, 3, time.Now())
+	attempt.Status = domain.StatusCompleted
+	attempt.Answers = map[string][]string{
+		"q1": {"1"},
+		"q2": {"2"},
+	}
+	mockAttemptRepo.On("Find", ctx, attempt.ID, user.ID).Return(attempt, nil)
+
+	questions := []domain.Question{
+		{ID: "q1", Domain: "Compute", CorrectAnswers: []string{"1"}},
+		{ID: "q2", Domain: "Security", CorrectAnswers: []string{"1"}},
+		{ID: "q3", Domain: "Security", CorrectAnswers: []string{"1"}},
+	}
+	mockQuestionRepo.On("FindByExamSetID", ctx, "cloud-digital-leader", "set1").Return(questions, nil)
+
+	in, _ := input.NewGetAttempt(user.ID, attempt.ID)
+	review, err := usecase.GetAttemptReview(ctx, in)
+
+	assert.NoError(t, err)
+	assert.Len(t, review.Questions, 3)
+	assert.Equal(t, output.ResultCorrect, review.Questions[0].Result)
+	assert.Equal(t, output.ResultIncorrect, review.Questions[1].Result)
+	assert.Equal(t, []string{"2"}, review.Questions[1].SelectedAnswers)
+	assert.Equal(t, output.ResultUnanswered, review.Questions[2].Result)
+	assert.Equal(t, []string{}, review.Questions[2].SelectedAnswers)
+}
+
+func TestUpdateAttempt_RecordsTimeSpent(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, new(MockUserStatsRepository), newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+
+	ctx := context.Background()
+	attempt, _ := domain.NewAttempt("attempt-1", "user-1", "cloud-digital-leader", "set-1", domain.ModeExam, 2, time.Now())
+	attempt.TimeSpent = map[string]int{"q1": 40, "q2": 10}
+
+	mockAttemptRepo.On("Find", ctx, "attempt-1", "user-1").Return(attempt, nil)
+	mockQuestionRepo.On("FindByExamSetID", ctx, "cloud-digital-leader", "set-1").Return([]domain.Question{{ID: "q1"}, {ID: "q2"}}, nil)
+
+	var saved domain.Attempt
+	mockAttemptRepo.On("Save", ctx, mock.MatchedBy(func(a domain.Attempt) bool {
+		saved = a
+		return true
+	})).Return(nil)
+
+	// q1 は通信順序の入れ替わりで古い累計値が届いたケース
+	err := usecase.UpdateAttempt(ctx, "user-1", "attempt-1", input.UpdateAttemptRequest{
+		TimeSpent: map[string]int{"q1": 30, "q2": 25},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"q1": 40, "q2": 25}, saved.TimeSpent)
+
+	err = usecase.UpdateAttempt(ctx, "user-1", "attempt-1", input.UpdateAttemptRequest{
+		TimeSpent: map[string]int{"q2": -1, "q9": 5},
+	})
+	var verr *domain.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Equal(t, []domain.FieldError{
+		{Field: "timeSpent.q2", Message: "回答時間は0以上で指定してください"},
+		{Field: "timeSpent.q9", Message: "この試験セットに存在しない問題です"},
+	}, verr.Fields)
+}
+
+func TestAttemptFlags_UpdatedAndSummarizedOnCompletion(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockStatsRepo := new(MockUserStatsRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, mockStatsRepo, newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+	ctx := context.Background()
+	userID := "user-1"
+	attempt, _ := domain.NewAttempt("attempt-1", userID, "cloud-digital-leader", "set-1", domain.ModeExam, 3, time.Now())
+	attempt.Answers = map[string][]string{"q1": {"a"}, "q2": {"b"}, "q3": {"a"}}
+
+	options := []domain.AnswerOption{{ID: "a"}, {ID: "b"}}
+	questions := []domain.Question{
+		{ID: "q1", Domain: "Compute", Options: options, CorrectAnswers: []string{"a"}},
+		{ID: "q2", Domain: "Compute", Options: options, CorrectAnswers: []string{"a"}},
+		{ID: "q3", Domain: "Compute", Options: options, CorrectAnswers: []string{"a"}},
+	}
+
+	mockUserRepo.On("Find", ctx, userID).Return(&domain.User{ID: userID, Role: domain.RoleFree}, nil)
+	mockAttemptRepo.On("Find", ctx, "attempt-1", userID).Return(attempt, nil)
+	mockQuestionRepo.On("FindByExamSetID", ctx, "cloud-digital-leader", "set-1").Return(questions, nil)
+	mockStatsRepo.On("Find", ctx, userID, "cloud-digital-leader").Return(nil, nil)
+	mockStatsRepo.On("Save", ctx, mock.AnythingOfType("domain.UserExamStats")).Return(nil)
+	mockAttemptRepo.On("Save", ctx, mock.AnythingOfType("domain.Attempt")).Return(nil)
+
+	flagged := []string{"q2", "q1", "q2"}
+	err := usecase.UpdateAttempt(ctx, userID, "attempt-1", input.UpdateAttemptRequest{FlaggedQuestionIDs: &flagged})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"q1", "q2"}, attempt.FlaggedQuestionIDs)
+
+	// フラグを省略した更新では既存のフラグを維持する
+	err = usecase.UpdateAttempt(ctx, userID, "attempt-1", input.UpdateAttemptRequest{CurrentIndex: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"q1", "q2"}, attempt.FlaggedQuestionIDs)
+
+	in, _ := input.NewCompleteAttempt(userID, "attempt-1", nil)
+	out, err := usecase.CompleteAttempt(ctx, in)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.FlagSummary{
+		Flagged:   domain.FlagGroupScore{CorrectCount: 1, TotalCount: 2, AccuracyRate: 50},
+		Unflagged: domain.FlagGroupScore{CorrectCount: 1, TotalCount: 1, AccuracyRate: 100},
+	}, out.FlagSummary)
+}
+
+func TestUserExamStats_AddAttemptAveragesTimeSpent(t *testing.T) {
+	stats, _ := domain.NewUserExamStats("user-1", "cloud-digital-leader")
+	completedAt := time.Now()
+
+	stats.AddAttempt(&domain.Attempt{
+		CompletedAt: &completedAt,
+		DomainScores: []domain.DomainScore{
+			{DomainName: "Compute", TotalCount: 2, TimeSpentSeconds: 60},
+			{DomainName: "Security", TotalCount: 1, TimeSpentSeconds: 90},
+		},
+	})
+	stats.AddAttempt(&domain.Attempt{
+		CompletedAt: &completedAt,
+		DomainScores: []domain.DomainScore{
+			{DomainName: "Compute", TotalCount: 2, TimeSpentSeconds: 40},
+		},
+	})
+
+	assert.Equal(t, 25.0, stats.DomainStats["Compute"].AvgTimeSeconds)
+	assert.Equal(t, 90.0, stats.DomainStats["Security"].AvgTimeSeconds)
+	assert.Equal(t, 190, stats.TotalTimeSpentSeconds)
+	assert.Equal(t, 38.0, stats.AvgTimeSeconds)
+}
+
+func TestStartAttempt_ExistingUnfinishedAttempt(t *testing.T) {
+	ctx := context.Background()
+	user := domain.NewUser("user123", "user@example.com", domain.ProviderGoogle)
+	questions := []domain.Question{{ID: "q1", ExamSetID: "set1", CorrectAnswers: []string{"1"}}}
+	unfinishedQuery := repository.AttemptQuery{
+		UserID:    user.ID,
+		ExamID:    "cloud-digital-leader",
+		ExamSetID: "set1",
+		Statuses:  domain.UnfinishedAttemptStatuses,
+		OrderBy:   repository.AttemptOrderUpdatedAt,
+		Limit:     maxUnfinishedAttempts,
+	}
+
+	t.Run("既定では既存の受験を再開する", func(t *testing.T) {
+		mockAttemptRepo := new(MockAttemptRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, new(MockUserStatsRepository), newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+		existing, _ := domain.NewAttempt("existing", user.ID, "cloud-digital-leader", "set1", domain.ModeExam, 1, time.Now())
+		mockUserRepo.On("Find", ctx, user.ID).Return(user, nil)
+		mockAttemptRepo.On("FindByUser", ctx, unfinishedQuery).Return([]domain.Attempt{*existing}, "", nil)
+
+		in, _ := input.NewCreateAttempt(user.ID, input.CreateAttemptRequest{ExamID: "cloud-digital-leader", ExamSetID: "set1"})
+		attempt, err := usecase.StartAttempt(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "existing", attempt.ID)
+		mockAttemptRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("形式の異なる受験は再開しない", func(t *testing.T) {
+		mockAttemptRepo := new(MockAttemptRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttemptUsecase(new(MockExamRepository), new(MockQuestionRepository), mockAttemptRepo, new(MockUserStatsRepository), newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+		existing, _ := domain.NewAttempt("existing", user.ID, "cloud-digital-leader", "set1", domain.ModeExam, 1, time.Now())
+		mockUserRepo.On("Find", ctx, user.ID).Return(user, nil)
+		mockAttemptRepo.On("FindByUser", ctx, unfinishedQuery).Return([]domain.Attempt{*existing}, "", nil)
+
+		in, _ := input.NewCreateAttempt(user.ID, input.CreateAttemptRequest{ExamID: "cloud-digital-leader", ExamSetID: "set1", Mode: domain.ModePractice})
+		_, err := usecase.StartAttempt(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrFailedPrecondition)
+		mockAttemptRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("abandon指定時は一覧の取得後に終了した受験を放棄しない", func(t *testing.T) {
+		mockAttemptRepo := new(MockAttemptRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewAttemptUsecase(mockExamRepo, mockQuestionRepo, mockAttemptRepo, new(MockUserStatsRepository), newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+		existing, _ := domain.NewAttempt("existing", user.ID, "cloud-digital-leader", "set1", domain.ModeExam, 1, time.Now())
+		completed := *existing
+		completed.Status = domain.StatusCompleted
+		mockUserRepo.On("Find", ctx, user.ID).Return(user, nil)
+		mockAttemptRepo.On("FindByUser", ctx, unfinishedQuery).Return([]domain.Attempt{*existing}, "", nil)
+		mockAttemptRepo.On("Find", ctx, "existing", user.ID).Return(&completed, nil)
+		mockExamRepo.On("Find", ctx, "cloud-digital-leader").Return(&domain.Exam{ID: "cloud-digital-leader"}, nil)
+		mockExamRepo.On("FindSet", ctx, "cloud-digital-leader", "set1").Return(&domain.ExamSet{ID: "set1", ExamID: "cloud-digital-leader"}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "cloud-digital-leader", "set1").Return(questions, nil)
+
+		var saved []string
+		mockAttemptRepo.On("Save", ctx, mock.MatchedBy(func(a domain.Attempt) bool {
+			saved = append(saved, a.ID)
+			return true
+		})).Return(nil)
+
+		in, _ := input.NewCreateAttempt(user.ID, input.CreateAttemptRequest{
+			ExamID:     "cloud-digital-leader",
+			ExamSetID:  "set1",
+			OnExisting: input.ExistingAttemptAbandon,
+		})
+		attempt, err := usecase.StartAttempt(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{attempt.ID}, saved)
+	})
+
+	t.Run("abandon指定時は既存の受験を放棄して新規に開始する", func(t *testing.T) {
+		mockAttemptRepo := new(MockAttemptRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewAttemptUsecase(mockExamRepo, mockQuestionRepo, mockAttemptRepo, new(MockUserStatsRepository), newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+		existing, _ := domain.NewAttempt("existing", user.ID, "cloud-digital-leader", "set1", domain.ModeExam, 1, time.Now())
+		mockUserRepo.On("Find", ctx, user.ID).Return(user, nil)
+		mockAttemptRepo.On("FindByUser", ctx, unfinishedQuery).Return([]domain.Attempt{*existing}, "", nil)
+		mockAttemptRepo.On("Find", ctx, "existing", user.ID).Return(existing, nil)
+		mockExamRepo.On("Find", ctx, "cloud-digital-leader").Return(&domain.Exam{ID: "cloud-digital-leader", DurationMinutes: 90}, nil)
+		mockExamRepo.On("FindSet", ctx, "cloud-digital-leader", "set1").Return(&domain.ExamSet{ID: "set1", ExamID: "cloud-digital-leader"}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "cloud-digital-leader", "set1").Return(questions, nil)
+
+		saved := map[string]domain.AttemptStatus{}
+		mockAttemptRepo.On("Save", ctx, mock.MatchedBy(func(a domain.Attempt) bool {
+			saved[a.ID] = a.Status
+			return true
+		})).Return(nil)
+
+		in, _ := input.NewCreateAttempt(user.ID, input.CreateAttemptRequest{
+			ExamID:     "cloud-digital-leader",
+			ExamSetID:  "set1",
+			OnExisting: input.ExistingAttemptAbandon,
+		})
+		attempt, err := usecase.StartAttempt(ctx, in)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, "existing", attempt.ID)
+		assert.Equal(t, domain.StatusAbandoned, saved["existing"])
+		assert.Equal(t, domain.StatusInProgress, saved[attempt.ID])
+		assert.NotNil(t, attempt.Deadline)
+		assert.Equal(t, 90*60, *attempt.RemainingSeconds)
+	})
+}
+
+func TestUpdateAttempt_RejectsAfterDeadlineAndCompletes(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockStatsRepo := new(MockUserStatsRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, mockStatsRepo, newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+	ctx := context.Background()
+	startedAt := time.Now().Add(-3 * time.Hour)
+	attempt, _ := domain.NewAttempt("attempt123", "user123", "cloud-digital-leader", "set1", domain.ModeExam, 2, startedAt)
+	attempt.SetTimeLimit(2 * time.Hour)
+	attempt.Answers = map[string][]string{"q1": {"1"}}
+
+	mockAttemptRepo.On("Find", ctx, attempt.ID, attempt.UserID).Return(attempt, nil)
+	mockQuestionRepo.On("FindByExamSetID", ctx, "cloud-digital-leader", "set1").Return([]domain.Question{
+		{ID: "q1", Domain: "Compute", CorrectAnswers: []string{"1"}},
+		{ID: "q2", Domain: "Compute", CorrectAnswers: []string{"1"}},
+	}, nil)
+	mockStatsRepo.On("Find", ctx, attempt.UserID, attempt.ExamID).Return(nil, nil)
+	mockStatsRepo.On("Save", ctx, mock.Anything).Return(nil)
+
+	var saved domain.Attempt
+	mockAttemptRepo.On("Save", ctx, mock.MatchedBy(func(a domain.Attempt) bool {
+		saved = a
+		return true
+	})).Return(nil)
+
+	// 期限後に送信された回答は保存も採点もされない
+	err := usecase.UpdateAttempt(ctx, attempt.UserID, attempt.ID, input.UpdateAttemptRequest{
+		Answers: map[string][]string{"q2": {"1"}},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrFailedPrecondition)
+	assert.Equal(t, domain.StatusCompleted, saved.Status)
+	assert.Equal(t, 1, saved.Score)
+	assert.Equal(t, *attempt.Deadline, *saved.CompletedAt)
+	assert.NotContains(t, saved.Answers, "q2")
+}
+
+func TestPauseResumeAttempt(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	usecase := NewAttemptUsecase(new(MockExamRepository), new(MockQuestionRepository), mockAttemptRepo, new(MockUserStatsRepository), newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+
+	ctx := context.Background()
+	startedAt := time.Now().Add(-30 * time.Minute)
+	attempt, _ := domain.NewAttempt("attempt123", "user123", "cloud-digital-leader", "set1", domain.ModeExam, 1, startedAt)
+	attempt.SetTimeLimit(time.Hour)
+	originalDeadline := *attempt.Deadline
+
+	mockAttemptRepo.On("Find", ctx, attempt.ID, attempt.UserID).Return(attempt, nil)
+	mockAttemptRepo.On("Save", ctx, mock.Anything).Return(nil)
+
+	in, _ := input.NewTransitionAttempt(attempt.UserID, attempt.ID)
+
+	paused, err := usecase.PauseAttempt(ctx, in)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusPaused, paused.Status)
+	assert.InDelta(t, 30*60, paused.ActiveSeconds, 1)
+
+	// 中断中の受験は再度中断できない
+	_, err = usecase.PauseAttempt(ctx, in)
+	assert.ErrorIs(t, err, domain.ErrFailedPrecondition)
+
+	// 中断中は回答を保存できない
+	err = usecase.UpdateAttempt(ctx, attempt.UserID, attempt.ID, input.UpdateAttemptRequest{})
+	assert.ErrorIs(t, err, domain.ErrFailedPrecondition)
+
+	// 中断中は回答を送信して完了することもできない
+	completeIn, _ := input.NewCompleteAttempt(attempt.UserID, attempt.ID, map[string][]string{"q1": {"a"}})
+	_, err = usecase.CompleteAttempt(ctx, completeIn)
+	assert.ErrorIs(t, err, domain.ErrFailedPrecondition)
+	assert.Equal(t, domain.StatusPaused, attempt.Status)
+
+	// 中断していた時間分だけ終了期限が延長される
+	pausedAt := time.Now().Add(-10 * time.Minute)
+	attempt.PausedAt = &pausedAt
+	resumed, err := usecase.ResumeAttempt(ctx, in)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusInProgress, resumed.Status)
+	assert.WithinDuration(t, originalDeadline.Add(10*time.Minute), *resumed.Deadline, time.Second)
+	assert.Nil(t, resumed.PausedAt)
+
+	abandoned, err := usecase.AbandonAttempt(ctx, in)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusAbandoned, abandoned.Status)
+
+	// 放棄した受験は再開できない
+	_, err = usecase.ResumeAttempt(ctx, in)
+	assert.ErrorIs(t, err, domain.ErrFailedPrecondition)
+}
+
+func TestNewListAttempts_Limit(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int
+		wantErr   bool
+	}{
+		{name: "省略時は既定の件数", limit: 0, wantLimit: input.DefaultAttemptListLimit},
+		{name: "1件は指定できる", limit: 1, wantLimit: 1},
+		{name: "上限ちょうどは指定できる", limit: input.MaxAttemptListLimit, wantLimit: input.MaxAttemptListLimit},
+		{name: "上限を超える場合はエラー", limit: input.MaxAttemptListLimit + 1, wantErr: true},
+		{name: "負の値はエラー", limit: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in, err := input.NewListAttempts("user-1", "", "", nil, "", tt.limit, "")
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, in.Limit)
+		})
+	}
+}
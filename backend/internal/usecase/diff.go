@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"time"
+
+	"nearline/backend/internal/usecase/output"
+)
+
+// diffFloatTolerance は浮動小数点の加算順序による誤差を差分として扱わないための許容値です。
+const diffFloatTolerance = 1e-6
+
+// diffValues は before と after を項目ごとに比較し、差分の一覧を返します。
+// 構造体は項目ごと、マップはキーごとに比較し、項目名は "DomainStats.Compute.CorrectCount" のようにドット区切りで表します。
+func diffValues(field string, before, after reflect.Value) []output.FieldDiff {
+	switch {
+	case before.Type() == reflect.TypeOf(time.Time{}):
+		if before.Interface().(time.Time).Equal(after.Interface().(time.Time)) {
+			return nil
+		}
+	case before.Kind() == reflect.Struct:
+		var diffs []output.FieldDiff
+		for i := range before.NumField() {
+			diffs = append(diffs, diffValues(joinField(field, before.Type().Field(i).Name), before.Field(i), after.Field(i))...)
+		}
+		return diffs
+	case before.Kind() == reflect.Map:
+		keys := make([]string, 0, before.Len()+after.Len())
+		for _, k := range append(before.MapKeys(), after.MapKeys()...) {
+			if !slices.Contains(keys, k.String()) {
+				keys = append(keys, k.String())
+			}
+		}
+		slices.Sort(keys)
+
+		var diffs []output.FieldDiff
+		zero := reflect.Zero(before.Type().Elem())
+		for _, k := range keys {
+			b := before.MapIndex(reflect.ValueOf(k))
+			if !b.IsValid() {
+				b = zero
+			}
+			a := after.MapIndex(reflect.ValueOf(k))
+			if !a.IsValid() {
+				a = zero
+			}
+			diffs = append(diffs, diffValues(joinField(field, k), b, a)...)
+		}
+		return diffs
+	case before.Kind() == reflect.Slice && before.Len() == 0 && after.Len() == 0:
+		// Firestore から読み込んだ空の配列と nil は区別しない
+		return nil
+	case before.Kind() == reflect.Float64:
+		if math.Abs(before.Float()-after.Float()) < diffFloatTolerance {
+			return nil
+		}
+	default:
+		if reflect.DeepEqual(before.Interface(), after.Interface()) {
+			return nil
+		}
+	}
+	return []output.FieldDiff{{Field: field, Before: before.Interface(), After: after.Interface()}}
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
@@ -3,14 +3,32 @@ package usecase
 import (
 	"context"
 
+	"github.com/samber/lo"
+
 	"nearline/backend/internal/domain"
 	"nearline/backend/internal/repository"
+	"nearline/backend/internal/usecase/input"
 )
 
 type ExamUsecase interface {
+	// ListExams は提供中の試験を表示順に返します。
 	ListExams(ctx context.Context) ([]domain.Exam, error)
 	GetExam(ctx context.Context, id string) (*domain.Exam, error)
 	ListExamSets(ctx context.Context, examID string) ([]domain.ExamSet, error)
+
+	// 以下は管理画面向けの試験・試験セットの管理
+	ListAllExams(ctx context.Context) ([]domain.Exam, error)
+	CreateExam(ctx context.Context, input *input.CreateExam) (*domain.Exam, error)
+	UpdateExam(ctx context.Context, input *input.UpdateExam) (*domain.Exam, error)
+	ReserveExamCodes(ctx context.Context) error
+	ReorderExams(ctx context.Context, input *input.ReorderExams) ([]domain.Exam, error)
+	RetireExam(ctx context.Context, input *input.GetExam) (*domain.Exam, error)
+	CreateExamSet(ctx context.Context, input *input.CreateExamSet) (*domain.ExamSet, error)
+	UpdateExamSet(ctx context.Context, input *input.UpdateExamSet) (*domain.ExamSet, error)
+	DeleteExamSet(ctx context.Context, input *input.GetExamSet) error
+	AddExamSetQuestions(ctx context.Context, input *input.UpdateExamSetQuestions) (*domain.ExamSet, error)
+	RemoveExamSetQuestions(ctx context.Context, input *input.UpdateExamSetQuestions) (*domain.ExamSet, error)
+	ReorderExamSetQuestions(ctx context.Context, input *input.UpdateExamSetQuestions) (*domain.ExamSet, error)
 }
 
 type examUsecase struct {
@@ -38,7 +56,13 @@ func NewExamUsecase(
 }
 
 func (u *examUsecase) ListExams(ctx context.Context) ([]domain.Exam, error) {
-	return u.examRepo.FindAll(ctx)
+	exams, err := u.examRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exams = lo.Reject(exams, func(e domain.Exam, _ int) bool { return e.IsRetired() })
+	domain.SortExams(exams)
+	return exams, nil
 }
 
 func (u *examUsecase) GetExam(ctx context.Context, id string) (*domain.Exam, error) {
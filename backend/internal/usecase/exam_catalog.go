@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/usecase/input"
+	"nearline/backend/internal/util"
+)
+
+// ListAllExams は管理画面向けに、提供を終了した試験を含むすべての試験を表示順に返します。
+func (u *examUsecase) ListAllExams(ctx context.Context) ([]domain.Exam, error) {
+	exams, err := u.examRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	domain.SortExams(exams)
+	return exams, nil
+}
+
+// CreateExam は試験を作成し、一覧の末尾に表示します。
+// 試験コードは問題IDの接頭辞に使うため、他の試験と重複する場合は作成しません。
+// 同じコードの試験が同時に作成された場合は、後から確定した方が domain.ErrAlreadyExists になります。
+func (u *examUsecase) CreateExam(ctx context.Context, input *input.CreateExam) (*domain.Exam, error) {
+	exam, err := domain.NewExam(input.ID, input.Code, input.Name, input.Description, input.ImageURL, input.DurationMinutes, input.PassingScore, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	exam.ScoringStrategy = input.ScoringStrategy
+	exam.DomainWeights = input.DomainWeights
+
+	exams, err := u.examRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if lo.ContainsBy(exams, func(e domain.Exam) bool { return e.ID == exam.ID }) {
+		return nil, errors.Wrapf(domain.ErrAlreadyExists, "試験 %s は既に存在します", exam.ID)
+	}
+	if err := validateExam(exam, exams); err != nil {
+		return nil, err
+	}
+	exam.DisplayOrder = lo.Max(util.Map(exams, func(e domain.Exam) int { return e.DisplayOrder })) + 1
+
+	// 一覧での重複確認の後に同じコードの試験が作成されることを防ぐため、試験コードを予約してから作成する
+	err = u.txRepo.Run(ctx, func(txCtx context.Context) error {
+		if err := u.examRepo.ReserveCode(txCtx, exam.Code, exam.ID); err != nil {
+			return err
+		}
+		return u.examRepo.Create(txCtx, *exam)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return exam, nil
+}
+
+// UpdateExam は試験の指定された項目を更新します。
+// 問題が登録済みの試験は、問題IDと試験コードの対応が崩れるため試験コードを変更できません。
+func (u *examUsecase) UpdateExam(ctx context.Context, input *input.UpdateExam) (*domain.Exam, error) {
+	exam, err := u.examRepo.Find(ctx, input.ExamID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousCode := exam.Code
+	if input.Code != nil && *input.Code != exam.Code {
+		questions, err := u.qRepo.FindByExamID(ctx, exam.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(questions) > 0 {
+			return nil, errors.Wrapf(domain.ErrFailedPrecondition, "問題が %d 問登録済みの試験のコードは変更できません", len(questions))
+		}
+		exam.Code = *input.Code
+	}
+	if input.Name != nil {
+		exam.Name = *input.Name
+	}
+	if input.Description != nil {
+		exam.Description = *input.Description
+	}
+	if input.ImageURL != nil {
+		exam.ImageURL = *input.ImageURL
+	}
+	if input.DurationMinutes != nil {
+		exam.DurationMinutes = *input.DurationMinutes
+	}
+	if input.ScoringStrategy != nil {
+		exam.ScoringStrategy = *input.ScoringStrategy
+	}
+	if input.PassingScore != nil {
+		exam.PassingScore = *input.PassingScore
+	}
+	if input.DomainWeights != nil {
+		exam.DomainWeights = *input.DomainWeights
+	}
+
+	exams, err := u.examRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateExam(exam, exams); err != nil {
+		return nil, err
+	}
+
+	exam.UpdatedAt = time.Now()
+	err = u.txRepo.Run(ctx, func(txCtx context.Context) error {
+		// 大文字・小文字のみの変更は同じ予約のまま扱う
+		if !strings.EqualFold(exam.Code, previousCode) {
+			if err := u.examRepo.ReserveCode(txCtx, exam.Code, exam.ID); err != nil {
+				return err
+			}
+			if err := u.examRepo.ReleaseCode(txCtx, previousCode, exam.ID); err != nil {
+				return err
+			}
+		}
+		return u.examRepo.Save(txCtx, *exam)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return exam, nil
+}
+
+// ReserveExamCodes はすべての試験について試験コードを予約します。
+// 予約による重複の防止を導入する前に作成された試験の予約を補うためのもので、予約済みの試験はそのままにします。
+// 他の試験と試験コードが重複していて予約できない試験がある場合は、残りの試験を予約したうえで domain.ErrAlreadyExists を返します。
+func (u *examUsecase) ReserveExamCodes(ctx context.Context) error {
+	exams, err := u.examRepo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	// 重複する試験コードは表示順が先の試験に予約する
+	domain.SortExams(exams)
+
+	var conflicts []string
+	for _, exam := range exams {
+		err := u.examRepo.ReserveCode(ctx, exam.Code, exam.ID)
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			conflicts = append(conflicts, exam.ID)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if len(conflicts) > 0 {
+		return errors.Wrapf(domain.ErrAlreadyExists, "試験コードが他の試験と重複しているため予約できませんでした: %s", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// ReorderExams は試験の表示順を、指定された試験IDの順に並べ替えます。
+func (u *examUsecase) ReorderExams(ctx context.Context, input *input.ReorderExams) ([]domain.Exam, error) {
+	exams, err := u.examRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := lo.KeyBy(exams, func(e domain.Exam) string { return e.ID })
+
+	verr := &domain.ValidationError{}
+	for i, id := range input.ExamIDs {
+		switch {
+		case slices.Index(input.ExamIDs, id) != i:
+			verr.Add("examIds", fmt.Sprintf("試験が重複しています: %s", id))
+		case !lo.HasKey(byID, id):
+			verr.Add("examIds", fmt.Sprintf("存在しない試験です: %s", id))
+		}
+	}
+	for _, e := range exams {
+		if !slices.Contains(input.ExamIDs, e.ID) {
+			verr.Add("examIds", fmt.Sprintf("並び順に含まれていない試験があります: %s", e.ID))
+		}
+	}
+	if err := verr.ErrOrNil(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reordered := make([]domain.Exam, 0, len(exams))
+	for i, id := range input.ExamIDs {
+		exam := byID[id]
+		if exam.DisplayOrder != i+1 {
+			exam.DisplayOrder = i + 1
+			exam.UpdatedAt = now
+		}
+		reordered = append(reordered, exam)
+	}
+
+	err = u.txRepo.Run(ctx, func(txCtx context.Context) error {
+		for _, exam := range reordered {
+			if exam.DisplayOrder == byID[exam.ID].DisplayOrder {
+				continue
+			}
+			if err := u.examRepo.Save(txCtx, exam); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reordered, nil
+}
+
+// RetireExam は試験の提供を終了します。
+// 終了した試験は一覧に表示されず新たに受験できなくなりますが、完了済みの受験や成績は引き続き参照できます。
+func (u *examUsecase) RetireExam(ctx context.Context, input *input.GetExam) (*domain.Exam, error) {
+	exam, err := u.examRepo.Find(ctx, input.ExamID)
+	if err != nil {
+		return nil, err
+	}
+	if exam.IsRetired() {
+		return exam, nil
+	}
+
+	exam.Retire(time.Now())
+	if err := u.examRepo.Save(ctx, *exam); err != nil {
+		return nil, err
+	}
+	return exam, nil
+}
+
+// validateExam は試験の設定を検証し、試験コードが他の試験と重複していないかを確認します。
+// 試験コードは大文字・小文字を区別せずに比較します。
+func validateExam(exam *domain.Exam, exams []domain.Exam) error {
+	verr := &domain.ValidationError{}
+	verr.Merge(exam.Validate())
+	for _, other := range exams {
+		if other.ID != exam.ID && strings.EqualFold(other.Code, exam.Code) {
+			verr.Add("code", fmt.Sprintf("試験コード %s は試験 %s で使用されています", exam.Code, other.ID))
+		}
+	}
+	return verr.ErrOrNil()
+}
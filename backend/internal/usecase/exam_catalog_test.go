@@ -0,0 +1,295 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/usecase/input"
+)
+
+func TestCreateExam(t *testing.T) {
+	ctx := context.Background()
+	existing := []domain.Exam{
+		{ID: "cloud-digital-leader", Code: "CDL", Name: "Cloud Digital Leader", DisplayOrder: 1},
+		{ID: "associate-cloud-engineer", Code: "ACE", Name: "Associate Cloud Engineer", DisplayOrder: 2},
+	}
+
+	t.Run("試験を作成し、一覧の末尾に表示する", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("FindAll", ctx).Return(existing, nil)
+		mockExamRepo.On("ReserveCode", ctx, "PCD", "professional-cloud-developer").Return(nil)
+		mockExamRepo.On("Create", ctx, mock.AnythingOfType("domain.Exam")).Return(nil)
+
+		in, _ := input.NewCreateExam(input.CreateExamRequest{ID: "professional-cloud-developer", Code: "PCD", Name: "Professional Cloud Developer", DurationMinutes: 120})
+		exam, err := usecase.CreateExam(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, exam.DisplayOrder)
+		assert.False(t, exam.IsRetired())
+		mockExamRepo.AssertExpectations(t)
+	})
+
+	t.Run("試験コードを予約できない場合は作成しない", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("FindAll", ctx).Return(existing, nil)
+		mockExamRepo.On("ReserveCode", ctx, "PCD", "professional-cloud-developer").Return(errors.Wrap(domain.ErrAlreadyExists, "試験コード PCD は試験 pcd で使用されています"))
+
+		in, _ := input.NewCreateExam(input.CreateExamRequest{ID: "professional-cloud-developer", Code: "PCD", Name: "Professional Cloud Developer"})
+		_, err := usecase.CreateExam(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+		mockExamRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("既に存在する試験IDでは作成しない", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("FindAll", ctx).Return(existing, nil)
+
+		in, _ := input.NewCreateExam(input.CreateExamRequest{ID: "cloud-digital-leader", Code: "CDL", Name: "Cloud Digital Leader"})
+		_, err := usecase.CreateExam(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+		mockExamRepo.AssertNotCalled(t, "ReserveCode", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("試験コードが他の試験と重複する場合は作成しない", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("FindAll", ctx).Return(existing, nil)
+
+		in, _ := input.NewCreateExam(input.CreateExamRequest{ID: "another-exam", Code: "ACE", Name: "Another"})
+		_, err := usecase.CreateExam(ctx, in)
+
+		var verr *domain.ValidationError
+		if assert.True(t, errors.As(err, &verr)) {
+			assert.Equal(t, []domain.FieldError{
+				{Field: "code", Message: "試験コード ACE は試験 associate-cloud-engineer で使用されています"},
+			}, verr.Fields)
+		}
+		mockExamRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("設定が不正な場合は項目ごとのエラーを返す", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("FindAll", ctx).Return(existing, nil)
+
+		in, _ := input.NewCreateExam(input.CreateExamRequest{
+			ID:            "new-exam",
+			Code:          "pcd-1",
+			DomainWeights: map[string]float64{"Compute": 0.5, "Security": 0},
+		})
+		_, err := usecase.CreateExam(ctx, in)
+
+		var verr *domain.ValidationError
+		if assert.True(t, errors.As(err, &verr)) {
+			assert.Equal(t, []domain.FieldError{
+				{Field: "code", Message: "試験コードは英大文字で始まる英大文字と数字で指定してください (例: PCD)"},
+				{Field: "name", Message: "試験名は必須です"},
+				{Field: "domainWeights.Security", Message: "配点比率は0より大きい値で指定してください"},
+			}, verr.Fields)
+		}
+	})
+
+	t.Run("試験IDの形式が不正な場合は作成しない", func(t *testing.T) {
+		usecase := NewExamUsecase(new(MockExamRepository), new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+
+		in, _ := input.NewCreateExam(input.CreateExamRequest{ID: "exams/pcd", Code: "PCD", Name: "PCD"})
+		_, err := usecase.CreateExam(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	})
+}
+
+func TestUpdateExam(t *testing.T) {
+	ctx := context.Background()
+	exam := func() *domain.Exam {
+		return &domain.Exam{ID: "professional-cloud-developer", Code: "PCD", Name: "Professional Cloud Developer"}
+	}
+
+	t.Run("問題が登録済みの試験のコードは変更できない", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewExamUsecase(mockExamRepo, mockQuestionRepo, new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("Find", ctx, "professional-cloud-developer").Return(exam(), nil)
+		mockQuestionRepo.On("FindByExamID", ctx, "professional-cloud-developer").Return([]domain.Question{{ID: "PCD_SET1_001"}}, nil)
+
+		in, _ := input.NewUpdateExam("professional-cloud-developer", input.UpdateExamRequest{Code: new(string)})
+		*in.Code = "PCDX"
+		_, err := usecase.UpdateExam(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrFailedPrecondition)
+		mockExamRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("試験コードを変更する場合は新しいコードを予約し、元のコードの予約を解除する", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewExamUsecase(mockExamRepo, mockQuestionRepo, new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("Find", ctx, "professional-cloud-developer").Return(exam(), nil)
+		mockQuestionRepo.On("FindByExamID", ctx, "professional-cloud-developer").Return([]domain.Question{}, nil)
+		mockExamRepo.On("FindAll", ctx).Return([]domain.Exam{*exam()}, nil)
+		mockExamRepo.On("ReserveCode", ctx, "PCDX", "professional-cloud-developer").Return(nil)
+		mockExamRepo.On("ReleaseCode", ctx, "PCD", "professional-cloud-developer").Return(nil)
+		mockExamRepo.On("Save", ctx, mock.AnythingOfType("domain.Exam")).Return(nil)
+
+		code := "PCDX"
+		in, _ := input.NewUpdateExam("professional-cloud-developer", input.UpdateExamRequest{Code: &code})
+		updated, err := usecase.UpdateExam(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "PCDX", updated.Code)
+		mockExamRepo.AssertExpectations(t)
+	})
+
+	t.Run("試験コードを変更しない場合は問題の有無を確認しない", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewExamUsecase(mockExamRepo, mockQuestionRepo, new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("Find", ctx, "professional-cloud-developer").Return(exam(), nil)
+		mockExamRepo.On("FindAll", ctx).Return([]domain.Exam{*exam()}, nil)
+		mockExamRepo.On("Save", ctx, mock.AnythingOfType("domain.Exam")).Return(nil)
+
+		name := "Professional Cloud Developer (2026)"
+		code := "PCD"
+		in, _ := input.NewUpdateExam("professional-cloud-developer", input.UpdateExamRequest{Code: &code, Name: &name})
+		updated, err := usecase.UpdateExam(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, name, updated.Name)
+		assert.False(t, updated.UpdatedAt.IsZero())
+		mockQuestionRepo.AssertNotCalled(t, "FindByExamID", mock.Anything, mock.Anything)
+		mockExamRepo.AssertNotCalled(t, "ReserveCode", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestReserveExamCodes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("予約のない既存の試験の試験コードを予約する", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		retiredAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockExamRepo.On("FindAll", ctx).Return([]domain.Exam{
+			{ID: "ace", Code: "ACE", DisplayOrder: 2},
+			{ID: "cdl", Code: "CDL", DisplayOrder: 1},
+			{ID: "old", Code: "OLD", DisplayOrder: 3, RetiredAt: &retiredAt},
+		}, nil)
+		mockExamRepo.On("ReserveCode", ctx, mock.Anything, mock.Anything).Return(nil)
+
+		err := usecase.ReserveExamCodes(ctx)
+
+		assert.NoError(t, err)
+		// 提供を終了した試験も、問題IDの接頭辞として試験コードを使用しているため予約する
+		mockExamRepo.AssertCalled(t, "ReserveCode", ctx, "CDL", "cdl")
+		mockExamRepo.AssertCalled(t, "ReserveCode", ctx, "ACE", "ace")
+		mockExamRepo.AssertCalled(t, "ReserveCode", ctx, "OLD", "old")
+	})
+
+	t.Run("試験コードが重複する試験は、残りの試験を予約したうえでエラー", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("FindAll", ctx).Return([]domain.Exam{
+			{ID: "pcd", Code: "PCD", DisplayOrder: 1},
+			{ID: "pcd-legacy", Code: "pcd", DisplayOrder: 2},
+			{ID: "ace", Code: "ACE", DisplayOrder: 3},
+		}, nil)
+		mockExamRepo.On("ReserveCode", ctx, "PCD", "pcd").Return(nil)
+		mockExamRepo.On("ReserveCode", ctx, "pcd", "pcd-legacy").Return(errors.Wrap(domain.ErrAlreadyExists, "試験コード pcd は試験 pcd で使用されています"))
+		mockExamRepo.On("ReserveCode", ctx, "ACE", "ace").Return(nil)
+
+		err := usecase.ReserveExamCodes(ctx)
+
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+		assert.Contains(t, err.Error(), "pcd-legacy")
+		mockExamRepo.AssertExpectations(t)
+	})
+}
+
+func TestReorderExams(t *testing.T) {
+	ctx := context.Background()
+	exams := []domain.Exam{
+		{ID: "cdl", DisplayOrder: 1},
+		{ID: "ace", DisplayOrder: 2},
+		{ID: "pcd", DisplayOrder: 3},
+	}
+
+	t.Run("指定された順に表示順を振り直し、変わった試験のみ保存する", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("FindAll", ctx).Return(exams, nil)
+		mockExamRepo.On("Save", ctx, mock.AnythingOfType("domain.Exam")).Return(nil)
+
+		in, _ := input.NewReorderExams(input.ReorderExamsRequest{ExamIDs: []string{"pcd", "ace", "cdl"}})
+		reordered, err := usecase.ReorderExams(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"pcd", "ace", "cdl"}, []string{reordered[0].ID, reordered[1].ID, reordered[2].ID})
+		assert.Equal(t, []int{1, 2, 3}, []int{reordered[0].DisplayOrder, reordered[1].DisplayOrder, reordered[2].DisplayOrder})
+		mockExamRepo.AssertNumberOfCalls(t, "Save", 2)
+	})
+
+	t.Run("すべての試験をちょうど1回ずつ指定する必要がある", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("FindAll", ctx).Return(exams, nil)
+
+		in, _ := input.NewReorderExams(input.ReorderExamsRequest{ExamIDs: []string{"pcd", "pcd", "unknown"}})
+		_, err := usecase.ReorderExams(ctx, in)
+
+		var verr *domain.ValidationError
+		if assert.True(t, errors.As(err, &verr)) {
+			assert.Equal(t, []domain.FieldError{
+				{Field: "examIds", Message: "試験が重複しています: pcd"},
+				{Field: "examIds", Message: "存在しない試験です: unknown"},
+				{Field: "examIds", Message: "並び順に含まれていない試験があります: cdl"},
+				{Field: "examIds", Message: "並び順に含まれていない試験があります: ace"},
+			}, verr.Fields)
+		}
+		mockExamRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRetireExam(t *testing.T) {
+	ctx := context.Background()
+	retiredAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("提供を終了した試験は一覧に表示しない", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("FindAll", ctx).Return([]domain.Exam{
+			{ID: "pcd", DisplayOrder: 2},
+			{ID: "old", DisplayOrder: 1, RetiredAt: &retiredAt},
+			{ID: "cdl", DisplayOrder: 1},
+		}, nil)
+
+		exams, err := usecase.ListExams(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cdl", "pcd"}, []string{exams[0].ID, exams[1].ID})
+	})
+
+	t.Run("提供を終了した試験は受験を開始できない", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockAttemptRepo := new(MockAttemptRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("Find", ctx, "user-1").Return(&domain.User{ID: "user-1", Role: domain.RoleAdmin}, nil)
+		mockAttemptRepo.On("FindByUser", ctx, mock.Anything).Return([]domain.Attempt{}, "", nil)
+		mockExamRepo.On("Find", ctx, "old").Return(&domain.Exam{ID: "old", RetiredAt: &retiredAt}, nil)
+		usecase := NewAttemptUsecase(mockExamRepo, new(MockQuestionRepository), mockAttemptRepo, new(MockUserStatsRepository), newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+		in, _ := input.NewCreateAttempt("user-1", input.CreateAttemptRequest{ExamID: "old", ExamSetID: "set1"})
+		_, err := usecase.StartAttempt(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrFailedPrecondition)
+	})
+}
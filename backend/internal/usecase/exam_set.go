@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+	"nearline/backend/internal/usecase/input"
+	"nearline/backend/internal/util"
+)
+
+// CreateExamSet は問題を含まない試験セットを作成します。
+func (u *examUsecase) CreateExamSet(ctx context.Context, input *input.CreateExamSet) (*domain.ExamSet, error) {
+	if _, err := u.examRepo.Find(ctx, input.ExamID); err != nil {
+		return nil, err
+	}
+
+	examSet, err := domain.NewExamSet(input.ID, input.ExamID, input.Name, input.Description, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	examSet.DurationMinutes = input.DurationMinutes
+	examSet.ScoringStrategy = input.ScoringStrategy
+	examSet.PassingScore = input.PassingScore
+	if err := examSet.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.examRepo.CreateSet(ctx, *examSet); err != nil {
+		return nil, err
+	}
+	return examSet, nil
+}
+
+// UpdateExamSet は試験セットの指定された項目を更新します。
+func (u *examUsecase) UpdateExamSet(ctx context.Context, input *input.UpdateExamSet) (*domain.ExamSet, error) {
+	examSet, err := u.examRepo.FindSet(ctx, input.ExamID, input.ExamSetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		examSet.Name = *input.Name
+	}
+	if input.Description != nil {
+		examSet.Description = *input.Description
+	}
+	if input.DurationMinutes != nil {
+		examSet.DurationMinutes = *input.DurationMinutes
+	}
+	if input.ScoringStrategy != nil {
+		examSet.ScoringStrategy = *input.ScoringStrategy
+	}
+	if input.PassingScore != nil {
+		examSet.PassingScore = *input.PassingScore
+	}
+	if err := examSet.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.examRepo.SaveSet(ctx, *examSet); err != nil {
+		return nil, err
+	}
+	return examSet, nil
+}
+
+// DeleteExamSet は試験セットを削除します。
+// 問題が含まれるセットは、問題を先に削除するか他のセットへ移動するまで削除できません。
+func (u *examUsecase) DeleteExamSet(ctx context.Context, input *input.GetExamSet) error {
+	examSet, err := findSyncedExamSet(ctx, u.examRepo, u.qRepo, input.ExamID, input.ExamSetID)
+	if err != nil {
+		return err
+	}
+	if len(examSet.QuestionIDs) > 0 {
+		return errors.Wrapf(domain.ErrFailedPrecondition, "問題が %d 問含まれる試験セットは削除できません", len(examSet.QuestionIDs))
+	}
+
+	return u.examRepo.DeleteSet(ctx, input.ExamID, input.ExamSetID)
+}
+
+// AddExamSetQuestions は試験の問題を試験セットの末尾に追加します。
+// 他のセットに含まれる問題は、そのセットから移動します。
+func (u *examUsecase) AddExamSetQuestions(ctx context.Context, input *input.UpdateExamSetQuestions) (*domain.ExamSet, error) {
+	examSet, err := findSyncedExamSet(ctx, u.examRepo, u.qRepo, input.ExamID, input.ExamSetID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := u.findExamQuestions(ctx, input.ExamID, input.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 移動元のセットからも問題を取り除く (移動元のセットが削除されている場合は何もしない)
+	sources := make(map[string]*domain.ExamSet)
+	for _, q := range questions {
+		if q.ExamSetID == "" || q.ExamSetID == examSet.ID {
+			continue
+		}
+		source, ok := sources[q.ExamSetID]
+		if !ok {
+			source, err = u.examRepo.FindSet(ctx, input.ExamID, q.ExamSetID)
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return nil, err
+			}
+			sources[q.ExamSetID] = source
+		}
+		if source != nil {
+			source.RemoveQuestionIDs(q.ID)
+		}
+	}
+	examSet.AddQuestionIDs(input.QuestionIDs...)
+
+	err = u.txRepo.Run(ctx, func(txCtx context.Context) error {
+		for _, q := range questions {
+			if q.ExamSetID == examSet.ID {
+				continue
+			}
+			q.ExamSetID = examSet.ID
+			if err := u.qRepo.Save(txCtx, q); err != nil {
+				return err
+			}
+		}
+		for _, source := range sources {
+			if source == nil {
+				continue
+			}
+			if err := u.examRepo.SaveSet(txCtx, *source); err != nil {
+				return err
+			}
+		}
+		return u.examRepo.SaveSet(txCtx, *examSet)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return examSet, nil
+}
+
+// RemoveExamSetQuestions は問題を試験セットから取り除きます。
+// 取り除いた問題は削除されず、試験の問題として適応型・間違いノートの出題対象に残ります。
+func (u *examUsecase) RemoveExamSetQuestions(ctx context.Context, input *input.UpdateExamSetQuestions) (*domain.ExamSet, error) {
+	examSet, err := findSyncedExamSet(ctx, u.examRepo, u.qRepo, input.ExamID, input.ExamSetID)
+	if err != nil {
+		return nil, err
+	}
+
+	verr := &domain.ValidationError{}
+	for _, id := range input.QuestionIDs {
+		if !lo.Contains(examSet.QuestionIDs, id) {
+			verr.Add("questionIds", fmt.Sprintf("試験セットに含まれない問題です: %s", id))
+		}
+	}
+	if err := verr.ErrOrNil(); err != nil {
+		return nil, err
+	}
+
+	questions, err := u.qRepo.FindByIDs(ctx, input.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+	examSet.RemoveQuestionIDs(input.QuestionIDs...)
+
+	err = u.txRepo.Run(ctx, func(txCtx context.Context) error {
+		for _, q := range questions {
+			q.ExamSetID = ""
+			if err := u.qRepo.Save(txCtx, q); err != nil {
+				return err
+			}
+		}
+		return u.examRepo.SaveSet(txCtx, *examSet)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return examSet, nil
+}
+
+// ReorderExamSetQuestions は試験セットの問題の出題順を並べ替えます。
+// 並べ替えは以後に開始する受験にのみ反映されます。
+func (u *examUsecase) ReorderExamSetQuestions(ctx context.Context, input *input.UpdateExamSetQuestions) (*domain.ExamSet, error) {
+	examSet, err := findSyncedExamSet(ctx, u.examRepo, u.qRepo, input.ExamID, input.ExamSetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := examSet.ReorderQuestions(input.QuestionIDs); err != nil {
+		return nil, err
+	}
+
+	if err := u.examRepo.SaveSet(ctx, *examSet); err != nil {
+		return nil, err
+	}
+	return examSet, nil
+}
+
+// findExamQuestions は指定された問題を ids の順に返します。
+// 存在しない問題や他の試験の問題が含まれる場合は domain.ValidationError を返します。
+func (u *examUsecase) findExamQuestions(ctx context.Context, examID string, ids []string) ([]domain.Question, error) {
+	questions, err := u.qRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := lo.KeyBy(questions, func(q domain.Question) string { return q.ID })
+
+	verr := &domain.ValidationError{}
+	for _, id := range ids {
+		q, ok := byID[id]
+		switch {
+		case !ok:
+			verr.Add("questionIds", fmt.Sprintf("存在しない問題です: %s", id))
+		case q.ExamID != examID:
+			verr.Add("questionIds", fmt.Sprintf("他の試験の問題です: %s", id))
+		}
+	}
+	if err := verr.ErrOrNil(); err != nil {
+		return nil, err
+	}
+
+	return util.Map(lo.Uniq(ids), func(id string) domain.Question { return byID[id] }), nil
+}
+
+// findSyncedExamSet は試験セットを取得し、QuestionIDs を実際にセットに含まれる問題と一致させて返します。
+// QuestionIDs が管理されていなかった既存のセットでも、出題順を維持したまま欠けている問題を末尾に補います。
+func findSyncedExamSet(ctx context.Context, examRepo repository.ExamRepository, qRepo repository.QuestionRepository, examID, examSetID string) (*domain.ExamSet, error) {
+	examSet, err := examRepo.FindSet(ctx, examID, examSetID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := qRepo.FindByExamSetID(ctx, examID, examSetID)
+	if err != nil {
+		return nil, err
+	}
+
+	examSet.QuestionIDs = util.Map(examSet.SortQuestions(questions), func(q domain.Question) string { return q.ID })
+	return examSet, nil
+}
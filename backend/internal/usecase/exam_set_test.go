@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/usecase/input"
+)
+
+func TestCreateExamSet(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("問題を含まない試験セットを作成する", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("Find", ctx, "pcd").Return(&domain.Exam{ID: "pcd"}, nil)
+		mockExamRepo.On("CreateSet", ctx, mock.AnythingOfType("domain.ExamSet")).Return(nil)
+
+		in, _ := input.NewCreateExamSet("pcd", input.CreateExamSetRequest{ID: "practice_exam_3", Name: "Practice Exam 3", PassingScore: 80})
+		examSet, err := usecase.CreateExamSet(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "pcd", examSet.ExamID)
+		assert.Equal(t, 80, examSet.PassingScore)
+		assert.Equal(t, []string{}, examSet.QuestionIDs)
+	})
+
+	t.Run("個別に生成するセットのIDは使用できない", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("Find", ctx, "pcd").Return(&domain.Exam{ID: "pcd"}, nil)
+
+		in, _ := input.NewCreateExamSet("pcd", input.CreateExamSetRequest{ID: domain.AdaptiveExamSetID, Name: "Adaptive"})
+		_, err := usecase.CreateExamSet(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+		mockExamRepo.AssertNotCalled(t, "CreateSet", mock.Anything, mock.Anything)
+	})
+
+	t.Run("設定が不正な場合は項目ごとのエラーを返す", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		usecase := NewExamUsecase(mockExamRepo, new(MockQuestionRepository), new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+		mockExamRepo.On("Find", ctx, "pcd").Return(&domain.Exam{ID: "pcd"}, nil)
+
+		in, _ := input.NewCreateExamSet("pcd", input.CreateExamSetRequest{ID: "set", ScoringStrategy: "unknown", PassingScore: 120})
+		_, err := usecase.CreateExamSet(ctx, in)
+
+		var verr *domain.ValidationError
+		if assert.True(t, errors.As(err, &verr)) {
+			assert.Equal(t, []domain.FieldError{
+				{Field: "name", Message: "試験セット名は必須です"},
+				{Field: "scoringStrategy", Message: "不正な採点方式です: unknown"},
+				{Field: "passingScore", Message: "合格ラインは0から100の範囲で指定してください"},
+			}, verr.Fields)
+		}
+	})
+}
+
+func TestNewGeneratedExamSet(t *testing.T) {
+	t.Run("個別に生成するセットを作成する", func(t *testing.T) {
+		for _, id := range []string{domain.AdaptiveExamSetID, domain.MistakesExamSetID} {
+			examSet, err := domain.NewGeneratedExamSet(id, "pcd")
+
+			assert.NoError(t, err)
+			assert.Equal(t, id, examSet.ID)
+			assert.Equal(t, "pcd", examSet.ExamID)
+		}
+	})
+
+	t.Run("個別に生成するセット以外のIDは使用できない", func(t *testing.T) {
+		_, err := domain.NewGeneratedExamSet("practice_exam_1", "pcd")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+	})
+}
+
+func TestExamSetQuestions(t *testing.T) {
+	ctx := context.Background()
+	question := func(id, setID string) domain.Question {
+		return domain.Question{ID: id, ExamID: "pcd", ExamSetID: setID}
+	}
+
+	t.Run("問題を追加し、移動元のセットからも取り除く", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewExamUsecase(mockExamRepo, mockQuestionRepo, new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+
+		mockExamRepo.On("FindSet", ctx, "pcd", "set1").Return(&domain.ExamSet{ID: "set1", ExamID: "pcd", QuestionIDs: []string{"q1"}}, nil)
+		mockExamRepo.On("FindSet", ctx, "pcd", "set2").Return(&domain.ExamSet{ID: "set2", ExamID: "pcd", QuestionIDs: []string{"q2", "q3"}}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "pcd", "set1").Return([]domain.Question{question("q1", "set1")}, nil)
+		mockQuestionRepo.On("FindByIDs", ctx, []string{"q3"}).Return([]domain.Question{question("q3", "set2")}, nil)
+
+		var saved []domain.ExamSet
+		mockExamRepo.On("SaveSet", ctx, mock.MatchedBy(func(s domain.ExamSet) bool {
+			saved = append(saved, s)
+			return true
+		})).Return(nil)
+		mockQuestionRepo.On("Save", ctx, question("q3", "set1")).Return(nil)
+
+		in, _ := input.NewUpdateExamSetQuestions("pcd", "set1", []string{"q3"})
+		examSet, err := usecase.AddExamSetQuestions(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"q1", "q3"}, examSet.QuestionIDs)
+		if assert.Len(t, saved, 2) {
+			assert.Equal(t, []string{"q2"}, saved[0].QuestionIDs)
+			assert.Equal(t, []string{"q1", "q3"}, saved[1].QuestionIDs)
+		}
+		mockQuestionRepo.AssertExpectations(t)
+	})
+
+	t.Run("存在しない問題や他の試験の問題は追加できない", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewExamUsecase(mockExamRepo, mockQuestionRepo, new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+
+		mockExamRepo.On("FindSet", ctx, "pcd", "set1").Return(&domain.ExamSet{ID: "set1", ExamID: "pcd"}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "pcd", "set1").Return([]domain.Question{}, nil)
+		mockQuestionRepo.On("FindByIDs", ctx, []string{"missing", "other"}).Return([]domain.Question{{ID: "other", ExamID: "ace"}}, nil)
+
+		in, _ := input.NewUpdateExamSetQuestions("pcd", "set1", []string{"missing", "other"})
+		_, err := usecase.AddExamSetQuestions(ctx, in)
+
+		var verr *domain.ValidationError
+		if assert.True(t, errors.As(err, &verr)) {
+			assert.Equal(t, []domain.FieldError{
+				{Field: "questionIds", Message: "存在しない問題です: missing"},
+				{Field: "questionIds", Message: "他の試験の問題です: other"},
+			}, verr.Fields)
+		}
+		mockExamRepo.AssertNotCalled(t, "SaveSet", mock.Anything, mock.Anything)
+	})
+
+	t.Run("問題を取り除いてもセット外の問題として残す", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewExamUsecase(mockExamRepo, mockQuestionRepo, new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+
+		mockExamRepo.On("FindSet", ctx, "pcd", "set1").Return(&domain.ExamSet{ID: "set1", ExamID: "pcd", QuestionIDs: []string{"q2", "q1"}}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "pcd", "set1").Return([]domain.Question{question("q1", "set1"), question("q2", "set1")}, nil)
+		mockQuestionRepo.On("FindByIDs", ctx, []string{"q2"}).Return([]domain.Question{question("q2", "set1")}, nil)
+		mockQuestionRepo.On("Save", ctx, question("q2", "")).Return(nil)
+		mockExamRepo.On("SaveSet", ctx, mock.Anything).Return(nil)
+
+		in, _ := input.NewUpdateExamSetQuestions("pcd", "set1", []string{"q2"})
+		examSet, err := usecase.RemoveExamSetQuestions(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"q1"}, examSet.QuestionIDs)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+
+	t.Run("QuestionIDs が未設定の既存のセットも並べ替えられる", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewExamUsecase(mockExamRepo, mockQuestionRepo, new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+
+		mockExamRepo.On("FindSet", ctx, "pcd", "set1").Return(&domain.ExamSet{ID: "set1", ExamID: "pcd", QuestionIDs: []string{}}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "pcd", "set1").Return([]domain.Question{question("q1", "set1"), question("q2", "set1")}, nil)
+		mockExamRepo.On("SaveSet", ctx, mock.Anything).Return(nil)
+
+		in, _ := input.NewUpdateExamSetQuestions("pcd", "set1", []string{"q2", "q1"})
+		examSet, err := usecase.ReorderExamSetQuestions(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"q2", "q1"}, examSet.QuestionIDs)
+	})
+
+	t.Run("並び順にセットの問題がすべて含まれない場合は並べ替えない", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewExamUsecase(mockExamRepo, mockQuestionRepo, new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+
+		mockExamRepo.On("FindSet", ctx, "pcd", "set1").Return(&domain.ExamSet{ID: "set1", ExamID: "pcd", QuestionIDs: []string{"q1", "q2"}}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "pcd", "set1").Return([]domain.Question{question("q1", "set1"), question("q2", "set1")}, nil)
+
+		in, _ := input.NewUpdateExamSetQuestions("pcd", "set1", []string{"q2", "q2"})
+		_, err := usecase.ReorderExamSetQuestions(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+		mockExamRepo.AssertNotCalled(t, "SaveSet", mock.Anything, mock.Anything)
+	})
+
+	t.Run("問題が含まれるセットは削除できない", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewExamUsecase(mockExamRepo, mockQuestionRepo, new(MockAttemptRepository), new(MockUserStatsRepository), new(MockTransactionRepository))
+
+		mockExamRepo.On("FindSet", ctx, "pcd", "set1").Return(&domain.ExamSet{ID: "set1", ExamID: "pcd"}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "pcd", "set1").Return([]domain.Question{question("q1", "set1")}, nil)
+
+		in, _ := input.NewGetExamSet("pcd", "set1")
+		err := usecase.DeleteExamSet(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrFailedPrecondition)
+		mockExamRepo.AssertNotCalled(t, "DeleteSet", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestQuestionUsecase_MaintainsExamSetQuestionIDs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("アップロードした問題をセットの末尾に追加する", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewQuestionUsecase(mockExamRepo, mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+
+		mockExamRepo.On("FindSet", ctx, "pcd", "SET1").Return(&domain.ExamSet{ID: "SET1", ExamID: "pcd", QuestionIDs: []string{"PCD_SET1_001"}}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "pcd", "SET1").Return([]domain.Question{{ID: "PCD_SET1_001", ExamID: "pcd", ExamSetID: "SET1"}}, nil)
+		mockQuestionRepo.On("FindByIDs", ctx, []string{"PCD_SET1_001", "PCD_SET1_002"}).Return([]domain.Question{}, nil)
+		mockQuestionRepo.On("BulkCreate", ctx, mock.Anything).Return(nil)
+		mockExamRepo.On("SaveSet", ctx, mock.MatchedBy(func(s domain.ExamSet) bool {
+			return assert.ObjectsAreEqual([]string{"PCD_SET1_001", "PCD_SET1_002"}, s.QuestionIDs)
+		})).Return(nil)
+
+		err := usecase.UploadQuestions(ctx, input.UploadQuestionsRequest{
+			ExamID:    "pcd",
+			ExamSetID: "SET1",
+			ExamCode:  "PCD",
+			Questions: []input.QuestionInput{
+				{Index: 1, QuestionText: "Q1"},
+				{Index: 2, QuestionText: "Q2"},
+			},
+		})
+
+		assert.NoError(t, err)
+		mockExamRepo.AssertExpectations(t)
+	})
+
+	t.Run("削除した問題をセットから取り除く", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewQuestionUsecase(mockExamRepo, mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+
+		mockQuestionRepo.On("Find", ctx, "q1").Return(&domain.Question{ID: "q1", ExamID: "pcd", ExamSetID: "set1"}, nil)
+		mockExamRepo.On("FindSet", ctx, "pcd", "set1").Return(&domain.ExamSet{ID: "set1", ExamID: "pcd", QuestionIDs: []string{"q1", "q2"}}, nil)
+		mockQuestionRepo.On("SaveRevision", ctx, mock.MatchedBy(func(r domain.QuestionRevision) bool {
+			return r.QuestionID == "q1" && r.Revision == 1
+		})).Return(nil)
+		mockQuestionRepo.On("Delete", ctx, "q1").Return(nil)
+		mockExamRepo.On("SaveSet", ctx, mock.MatchedBy(func(s domain.ExamSet) bool {
+			return assert.ObjectsAreEqual([]string{"q2"}, s.QuestionIDs)
+		})).Return(nil)
+
+		in, _ := input.NewGetQuestion("q1")
+		err := usecase.DeleteQuestion(ctx, in)
+
+		assert.NoError(t, err)
+		mockExamRepo.AssertExpectations(t)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
@@ -2,10 +2,75 @@ package input
 
 import (
 	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
 
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
 )
 
+// CreateAttempt は受験の開始条件です。
+type CreateAttempt struct {
+	UserID    string
+	ExamID    string
+	ExamSetID string
+	Mode      domain.AttemptMode
+	// AbandonExisting が true の場合は、同じセットの未完了の受験を再開せずに放棄して新たに開始します。
+	AbandonExisting bool
+	// QuestionCount は個別に生成するセット (適応型・間違いノート) の最大出題数です。それ以外のセットでは0です。
+	QuestionCount int
+}
+
+// NewCreateAttempt は受験の開始条件を生成します。
+// 省略された項目には既定値を補い、間違いノートからの受験は復習が目的のため常に練習形式とします。
+func NewCreateAttempt(userID string, req CreateAttemptRequest) (*CreateAttempt, error) {
+	if userID == "" {
+		return nil, errors.Wrap(domain.ErrUnauthenticated, "userID is required")
+	}
+	if req.ExamID == "" || req.ExamSetID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examId and examSetId are required")
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = domain.ModeExam
+	}
+	if !lo.Contains(domain.AttemptModeValues(), mode) {
+		return nil, errors.Wrapf(domain.ErrInvalidArgument, "invalid mode: %s", req.Mode)
+	}
+	if req.ExamSetID == domain.MistakesExamSetID {
+		mode = domain.ModePractice
+	}
+
+	onExisting := req.OnExisting
+	if onExisting == "" {
+		onExisting = ExistingAttemptResume
+	}
+	if onExisting != ExistingAttemptResume && onExisting != ExistingAttemptAbandon {
+		return nil, errors.Wrapf(domain.ErrInvalidArgument, "invalid onExisting: %s", req.OnExisting)
+	}
+
+	// 出題数は個別に生成するセットでのみ使用する
+	questionCount := 0
+	if req.ExamSetID == domain.AdaptiveExamSetID || req.ExamSetID == domain.MistakesExamSetID {
+		questionCount = req.QuestionCount
+		if questionCount == 0 {
+			questionCount = domain.DefaultAdaptiveQuestionCount
+		}
+		if questionCount < 0 || questionCount > domain.MaxAdaptiveQuestionCount {
+			return nil, errors.Wrapf(domain.ErrInvalidArgument, "questionCount must be between 1 and %d", domain.MaxAdaptiveQuestionCount)
+		}
+	}
+
+	return &CreateAttempt{
+		UserID:          userID,
+		ExamID:          req.ExamID,
+		ExamSetID:       req.ExamSetID,
+		Mode:            mode,
+		AbandonExisting: onExisting == ExistingAttemptAbandon,
+		QuestionCount:   questionCount,
+	}, nil
+}
+
 type CompleteAttempt struct {
 	UserID    string
 	AttemptID string
@@ -26,3 +91,100 @@ func NewCompleteAttempt(userID, attemptID string, answers map[string][]string) (
 		Answers:   answers,
 	}, nil
 }
+
+type GetAttempt struct {
+	UserID    string
+	AttemptID string
+}
+
+func NewGetAttempt(userID, attemptID string) (*GetAttempt, error) {
+	if userID == "" {
+		return nil, errors.Wrap(domain.ErrUnauthenticated, "userID is required")
+	}
+	if attemptID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "attemptID is required")
+	}
+
+	return &GetAttempt{
+		UserID:    userID,
+		AttemptID: attemptID,
+	}, nil
+}
+
+// TransitionAttempt は受験の中断・再開・放棄の対象を指定します。
+type TransitionAttempt struct {
+	UserID    string
+	AttemptID string
+}
+
+func NewTransitionAttempt(userID, attemptID string) (*TransitionAttempt, error) {
+	if userID == "" {
+		return nil, errors.Wrap(domain.ErrUnauthenticated, "userID is required")
+	}
+	if attemptID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "attemptID is required")
+	}
+
+	return &TransitionAttempt{
+		UserID:    userID,
+		AttemptID: attemptID,
+	}, nil
+}
+
+const (
+	DefaultAttemptListLimit = 20
+	MaxAttemptListLimit     = 100
+)
+
+type ListAttempts struct {
+	UserID    string
+	ExamID    string
+	ExamSetID string
+	Statuses  []domain.AttemptStatus
+	OrderBy   repository.AttemptOrder
+	Limit     int
+	Cursor    string
+}
+
+// NewListAttempts は受験履歴一覧の検索条件を生成します。
+// limit が0の場合は DefaultAttemptListLimit を、orderBy が空の場合は更新日時順を使用します。
+func NewListAttempts(userID, examID, examSetID string, statuses []string, orderBy string, limit int, cursor string) (*ListAttempts, error) {
+	if userID == "" {
+		return nil, errors.Wrap(domain.ErrUnauthenticated, "userID is required")
+	}
+
+	attemptStatuses := make([]domain.AttemptStatus, 0, len(statuses))
+	for _, s := range statuses {
+		status := domain.AttemptStatus(s)
+		if !lo.Contains(domain.AttemptStatusValues(), status) {
+			return nil, errors.Wrapf(domain.ErrInvalidArgument, "invalid status: %s", s)
+		}
+		attemptStatuses = append(attemptStatuses, status)
+	}
+
+	order := repository.AttemptOrder(orderBy)
+	switch order {
+	case "":
+		order = repository.AttemptOrderUpdatedAt
+	case repository.AttemptOrderUpdatedAt, repository.AttemptOrderStartedAt:
+	default:
+		return nil, errors.Wrapf(domain.ErrInvalidArgument, "invalid sort: %s", orderBy)
+	}
+
+	if limit == 0 {
+		limit = DefaultAttemptListLimit
+	}
+	if limit < 0 || limit > MaxAttemptListLimit {
+		return nil, errors.Wrapf(domain.ErrInvalidArgument, "limit must be between 1 and %d", MaxAttemptListLimit)
+	}
+
+	return &ListAttempts{
+		UserID:    userID,
+		ExamID:    examID,
+		ExamSetID: examSetID,
+		Statuses:  attemptStatuses,
+		OrderBy:   order,
+		Limit:     limit,
+		Cursor:    cursor,
+	}, nil
+}
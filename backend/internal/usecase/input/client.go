@@ -1,15 +1,36 @@
 package input
 
+import "nearline/backend/internal/domain"
+
 type CreateAttemptRequest struct {
-	ExamID    string `json:"examId"`
-	ExamSetID string `json:"examSetId"`
+	ExamID     string             `json:"examId"`
+	ExamSetID  string             `json:"examSetId"`
+	Mode       domain.AttemptMode `json:"mode"`       // 省略時は "exam"
+	OnExisting ExistingAttempt    `json:"onExisting"` // 同じセットの未完了の受験がある場合の扱い。省略時は "resume"
+	// QuestionCount は examSetId に domain.AdaptiveExamSetID または domain.MistakesExamSetID を指定した場合の最大出題数です。
+	// 省略時は domain.DefaultAdaptiveQuestionCount
+	QuestionCount int `json:"questionCount"`
 }
 
+// ExistingAttempt は受験開始時に、同じセットの未完了の受験が既に存在する場合の扱いを指定します。
+type ExistingAttempt string
+
+const (
+	ExistingAttemptResume  ExistingAttempt = "resume"  // 既存の受験を返して再開する (形式が異なる場合は再開せずにエラーを返す)
+	ExistingAttemptAbandon ExistingAttempt = "abandon" // 既存の受験を放棄し、新しい受験を開始する
+)
+
 type UpdateAttemptRequest struct {
-	CurrentIndex int                 `json:"currentIndex"`
-	Answers      map[string][]string `json:"answers"` // Key: QuestionID
+	CurrentIndex       int                 `json:"currentIndex"`
+	Answers            map[string][]string `json:"answers"`            // Key: QuestionID
+	TimeSpent          map[string]int      `json:"timeSpent"`          // Key: QuestionID, Value: 累計回答時間(秒)
+	FlaggedQuestionIDs *[]string           `json:"flaggedQuestionIds"` // 見直しフラグを付けた問題IDの一覧。指定した場合は置き換え、省略時は変更しない
 }
 
 type CompleteAttemptRequest struct {
 	Answers map[string][]string `json:"answers"` // Final answers
 }
+
+type RecordReviewRequest struct {
+	SelectedAnswers []string `json:"selectedAnswers"` // 選択した選択肢ID
+}
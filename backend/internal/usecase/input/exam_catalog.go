@@ -0,0 +1,94 @@
+package input
+
+import (
+	"nearline/backend/internal/domain"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+)
+
+// CreateExamRequest は試験作成APIのリクエストボディです。
+type CreateExamRequest struct {
+	ID              string                 `json:"id"`   // 例: "professional-cloud-developer"
+	Code            string                 `json:"code"` // 例: "PCD"。問題IDの接頭辞に使うため、他の試験と重複できない
+	Name            string                 `json:"name"`
+	Description     string                 `json:"description"`
+	ImageURL        string                 `json:"imageUrl"`
+	DurationMinutes int                    `json:"durationMinutes"` // 0の場合は無制限
+	ScoringStrategy domain.ScoringStrategy `json:"scoringStrategy"` // 省略時は all_or_nothing
+	PassingScore    int                    `json:"passingScore"`    // 0の場合は DefaultPassingScore
+	DomainWeights   map[string]float64     `json:"domainWeights"`   // 省略時は全問均等
+}
+
+type CreateExam struct {
+	CreateExamRequest
+}
+
+func NewCreateExam(req CreateExamRequest) (*CreateExam, error) {
+	if req.ID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "id is required")
+	}
+
+	return &CreateExam{CreateExamRequest: req}, nil
+}
+
+// UpdateExamRequest は試験の部分更新です。省略した項目は変更しません。
+// 表示順と提供終了は専用のAPIで変更します。
+type UpdateExamRequest struct {
+	Code            *string                 `json:"code"` // 問題が登録済みの試験では変更できない
+	Name            *string                 `json:"name"`
+	Description     *string                 `json:"description"`
+	ImageURL        *string                 `json:"imageUrl"`
+	DurationMinutes *int                    `json:"durationMinutes"`
+	ScoringStrategy *domain.ScoringStrategy `json:"scoringStrategy"`
+	PassingScore    *int                    `json:"passingScore"`
+	DomainWeights   *map[string]float64     `json:"domainWeights"`
+}
+
+type UpdateExam struct {
+	ExamID string
+	UpdateExamRequest
+}
+
+func NewUpdateExam(examID string, req UpdateExamRequest) (*UpdateExam, error) {
+	if examID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examID is required")
+	}
+
+	return &UpdateExam{
+		ExamID:            examID,
+		UpdateExamRequest: req,
+	}, nil
+}
+
+type GetExam struct {
+	ExamID string
+}
+
+func NewGetExam(examID string) (*GetExam, error) {
+	if examID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examID is required")
+	}
+
+	return &GetExam{ExamID: examID}, nil
+}
+
+// ReorderExamsRequest は試験の表示順の変更APIのリクエストボディです。
+type ReorderExamsRequest struct {
+	ExamIDs []string `json:"examIds"` // 提供を終了した試験を含むすべての試験を、表示したい順に指定する
+}
+
+type ReorderExams struct {
+	ExamIDs []string
+}
+
+func NewReorderExams(req ReorderExamsRequest) (*ReorderExams, error) {
+	if len(req.ExamIDs) == 0 {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examIds is required")
+	}
+	if lo.Contains(req.ExamIDs, "") {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examIds must not contain an empty ID")
+	}
+
+	return &ReorderExams{ExamIDs: req.ExamIDs}, nil
+}
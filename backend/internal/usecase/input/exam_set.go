@@ -0,0 +1,116 @@
+package input
+
+import (
+	"nearline/backend/internal/domain"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+)
+
+// CreateExamSetRequest は試験セット作成APIのリクエストボディです。
+type CreateExamSetRequest struct {
+	ID              string                 `json:"id"` // 例: "practice_exam_3"
+	Name            string                 `json:"name"`
+	Description     string                 `json:"description"`
+	DurationMinutes int                    `json:"durationMinutes"` // 0の場合は試験の設定を使用
+	ScoringStrategy domain.ScoringStrategy `json:"scoringStrategy"` // 省略時は試験の設定を使用
+	PassingScore    int                    `json:"passingScore"`    // 0の場合は試験の設定を使用
+}
+
+type CreateExamSet struct {
+	ExamID string
+	CreateExamSetRequest
+}
+
+func NewCreateExamSet(examID string, req CreateExamSetRequest) (*CreateExamSet, error) {
+	if examID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examID is required")
+	}
+	if req.ID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "id is required")
+	}
+
+	return &CreateExamSet{
+		ExamID:               examID,
+		CreateExamSetRequest: req,
+	}, nil
+}
+
+// UpdateExamSetRequest は試験セットの部分更新です。省略した項目は変更しません。
+// 含まれる問題と出題順は専用のAPIで変更します。
+type UpdateExamSetRequest struct {
+	Name            *string                 `json:"name"`
+	Description     *string                 `json:"description"`
+	DurationMinutes *int                    `json:"durationMinutes"`
+	ScoringStrategy *domain.ScoringStrategy `json:"scoringStrategy"`
+	PassingScore    *int                    `json:"passingScore"`
+}
+
+type UpdateExamSet struct {
+	ExamID    string
+	ExamSetID string
+	UpdateExamSetRequest
+}
+
+func NewUpdateExamSet(examID, examSetID string, req UpdateExamSetRequest) (*UpdateExamSet, error) {
+	if examID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examID is required")
+	}
+	if examSetID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examSetID is required")
+	}
+
+	return &UpdateExamSet{
+		ExamID:               examID,
+		ExamSetID:            examSetID,
+		UpdateExamSetRequest: req,
+	}, nil
+}
+
+type GetExamSet struct {
+	ExamID    string
+	ExamSetID string
+}
+
+func NewGetExamSet(examID, examSetID string) (*GetExamSet, error) {
+	if examID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examID is required")
+	}
+	if examSetID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examSetID is required")
+	}
+
+	return &GetExamSet{ExamID: examID, ExamSetID: examSetID}, nil
+}
+
+// ExamSetQuestionsRequest は試験セットの問題の追加・並べ替えAPIのリクエストボディです。
+type ExamSetQuestionsRequest struct {
+	QuestionIDs []string `json:"questionIds"`
+}
+
+type UpdateExamSetQuestions struct {
+	ExamID      string
+	ExamSetID   string
+	QuestionIDs []string
+}
+
+func NewUpdateExamSetQuestions(examID, examSetID string, questionIDs []string) (*UpdateExamSetQuestions, error) {
+	if examID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examID is required")
+	}
+	if examSetID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examSetID is required")
+	}
+	if len(questionIDs) == 0 {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "questionIds is required")
+	}
+	if lo.Contains(questionIDs, "") {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "questionIds must not contain an empty ID")
+	}
+
+	return &UpdateExamSetQuestions{
+		ExamID:      examID,
+		ExamSetID:   examSetID,
+		QuestionIDs: questionIDs,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package input
+
+import (
+	"nearline/backend/internal/domain"
+
+	"github.com/cockroachdb/errors"
+)
+
+type GetItemAnalysis struct {
+	ExamID       string
+	MinResponses int  // 懸念点の判定に必要な最小の回答数
+	OutliersOnly bool // true の場合は懸念点のある問題のみ返す
+}
+
+// NewGetItemAnalysis は項目分析の取得条件を生成します。minResponses が0の場合は domain.DefaultItemMinResponses を使用します。
+func NewGetItemAnalysis(examID string, minResponses int, includeAll bool) (*GetItemAnalysis, error) {
+	if examID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examID is required")
+	}
+	if minResponses < 0 {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "minResponses must not be negative")
+	}
+	if minResponses == 0 {
+		minResponses = domain.DefaultItemMinResponses
+	}
+
+	return &GetItemAnalysis{
+		ExamID:       examID,
+		MinResponses: minResponses,
+		OutliersOnly: !includeAll,
+	}, nil
+}
@@ -0,0 +1,28 @@
+package input
+
+import (
+	"nearline/backend/internal/domain"
+
+	"github.com/cockroachdb/errors"
+)
+
+type ListMistakes struct {
+	UserID string
+	ExamID string
+	Domain string // 空の場合はすべての分野
+}
+
+func NewListMistakes(userID, examID, domainName string) (*ListMistakes, error) {
+	if userID == "" {
+		return nil, errors.Wrap(domain.ErrUnauthenticated, "userID is required")
+	}
+	if examID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examId is required")
+	}
+
+	return &ListMistakes{
+		UserID: userID,
+		ExamID: examID,
+		Domain: domainName,
+	}, nil
+}
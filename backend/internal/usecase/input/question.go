@@ -7,15 +7,71 @@ import (
 )
 
 type GetExamQuestions struct {
+	UserID    string
+	ExamID    string
 	ExamSetID string
 }
 
-func NewGetExamQuestions(examSetID string) (*GetExamQuestions, error) {
+func NewGetExamQuestions(userID, examID, examSetID string) (*GetExamQuestions, error) {
+	if userID == "" {
+		return nil, errors.Wrap(domain.ErrUnauthenticated, "userID is required")
+	}
+	if examID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examID is required")
+	}
 	if examSetID == "" {
 		return nil, errors.Wrap(domain.ErrInvalidArgument, "examSetID is required")
 	}
 
 	return &GetExamQuestions{
+		UserID:    userID,
+		ExamID:    examID,
 		ExamSetID: examSetID,
 	}, nil
 }
+
+type GetQuestion struct {
+	QuestionID string
+}
+
+func NewGetQuestion(questionID string) (*GetQuestion, error) {
+	if questionID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "questionID is required")
+	}
+
+	return &GetQuestion{QuestionID: questionID}, nil
+}
+
+// UpdateQuestionRequest は問題の部分更新です。省略した項目は変更しません。
+// ID・ExamID・ExamSetID は回答履歴と紐づくため変更できません。
+type UpdateQuestionRequest struct {
+	QuestionText       *string        `json:"question"`
+	QuestionType       *string        `json:"questionType"`
+	Options            *[]OptionInput `json:"answerOptions"`
+	CorrectAnswers     *[]string      `json:"correctAnswers"`
+	OverallExplanation *string        `json:"overallExplanation"`
+	Domain             *string        `json:"domain"`
+	ImageURL           *string        `json:"imageUrl"`
+	ReferenceURLs      *[]string      `json:"referenceUrls"`
+}
+
+type UpdateQuestion struct {
+	QuestionID string
+	UpdatedBy  string
+	UpdateQuestionRequest
+}
+
+func NewUpdateQuestion(questionID, updatedBy string, req UpdateQuestionRequest) (*UpdateQuestion, error) {
+	if updatedBy == "" {
+		return nil, errors.Wrap(domain.ErrUnauthenticated, "updatedBy is required")
+	}
+	if questionID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "questionID is required")
+	}
+
+	return &UpdateQuestion{
+		QuestionID:            questionID,
+		UpdatedBy:             updatedBy,
+		UpdateQuestionRequest: req,
+	}, nil
+}
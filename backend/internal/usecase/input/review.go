@@ -0,0 +1,64 @@
+package input
+
+import (
+	"nearline/backend/internal/domain"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	DefaultReviewQueueLimit = 20
+	MaxReviewQueueLimit     = 100
+)
+
+type GetReviewQueue struct {
+	UserID string
+	ExamID string
+	Limit  int
+}
+
+// NewGetReviewQueue は復習キューの取得条件を生成します。limit が0の場合は DefaultReviewQueueLimit を使用します。
+func NewGetReviewQueue(userID, examID string, limit int) (*GetReviewQueue, error) {
+	if userID == "" {
+		return nil, errors.Wrap(domain.ErrUnauthenticated, "userID is required")
+	}
+	if examID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examId is required")
+	}
+	if limit < 0 || limit > MaxReviewQueueLimit {
+		return nil, errors.Wrapf(domain.ErrInvalidArgument, "limit must be between 1 and %d", MaxReviewQueueLimit)
+	}
+	if limit == 0 {
+		limit = DefaultReviewQueueLimit
+	}
+
+	return &GetReviewQueue{
+		UserID: userID,
+		ExamID: examID,
+		Limit:  limit,
+	}, nil
+}
+
+type RecordReview struct {
+	UserID          string
+	QuestionID      string
+	SelectedAnswers []string
+}
+
+func NewRecordReview(userID, questionID string, selectedAnswers []string) (*RecordReview, error) {
+	if userID == "" {
+		return nil, errors.Wrap(domain.ErrUnauthenticated, "userID is required")
+	}
+	if questionID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "questionID is required")
+	}
+	if len(selectedAnswers) == 0 {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "selectedAnswers is required")
+	}
+
+	return &RecordReview{
+		UserID:          userID,
+		QuestionID:      questionID,
+		SelectedAnswers: selectedAnswers,
+	}, nil
+}
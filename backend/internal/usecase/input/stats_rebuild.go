@@ -0,0 +1,31 @@
+package input
+
+import (
+	"nearline/backend/internal/domain"
+
+	"github.com/cockroachdb/errors"
+)
+
+// RebuildStatsRequest は統計再集計APIのリクエストボディです。
+type RebuildStatsRequest struct {
+	UserID string `json:"userId"` // 省略時は全ユーザーが対象
+	DryRun bool   `json:"dryRun"` // true の場合は保存せず差分のみ返す
+}
+
+type RebuildStats struct {
+	ExamID string
+	UserID string
+	DryRun bool
+}
+
+func NewRebuildStats(examID, userID string, dryRun bool) (*RebuildStats, error) {
+	if examID == "" {
+		return nil, errors.Wrap(domain.ErrInvalidArgument, "examID is required")
+	}
+
+	return &RebuildStats{
+		ExamID: examID,
+		UserID: userID,
+		DryRun: dryRun,
+	}, nil
+}
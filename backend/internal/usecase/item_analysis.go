@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/samber/lo"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+	"nearline/backend/internal/usecase/input"
+	"nearline/backend/internal/usecase/output"
+)
+
+type ItemAnalysisUsecase interface {
+	// GetItemAnalysis は試験の問題ごとの難易度・識別力と、誤りの疑いがある問題などの懸念点を返します。
+	// 統計は問題の現在の版への回答のみから算出します。
+	GetItemAnalysis(ctx context.Context, input *input.GetItemAnalysis) (*output.ItemAnalysis, error)
+}
+
+type itemAnalysisUsecase struct {
+	qRepo  repository.QuestionRepository
+	qsRepo repository.QuestionStatsRepository
+}
+
+func NewItemAnalysisUsecase(qRepo repository.QuestionRepository, qsRepo repository.QuestionStatsRepository) ItemAnalysisUsecase {
+	return &itemAnalysisUsecase{qRepo: qRepo, qsRepo: qsRepo}
+}
+
+func (u *itemAnalysisUsecase) GetItemAnalysis(ctx context.Context, input *input.GetItemAnalysis) (*output.ItemAnalysis, error) {
+	questions, err := u.qRepo.FindByExamID(ctx, input.ExamID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := u.qsRepo.FindByExamID(ctx, input.ExamID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 編集前の版への回答は現在の問題の分析に含めない
+	statsByRevision := lo.KeyBy(stats, func(s domain.QuestionStats) string {
+		return fmt.Sprintf("%s@%d", s.QuestionID, s.QuestionRevision())
+	})
+
+	items := []output.ItemAnalysisItem{}
+	for _, q := range questions {
+		s, ok := statsByRevision[fmt.Sprintf("%s@%d", q.ID, q.CurrentRevision())]
+		if !ok {
+			continue // 現在の版への回答がまだない問題
+		}
+		flags := s.Analyze(q, input.MinResponses)
+		if input.OutliersOnly && len(flags) == 0 {
+			continue
+		}
+		items = append(items, output.NewItemAnalysisItem(q, &s, flags))
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if len(items[i].Flags) != len(items[j].Flags) {
+			return len(items[i].Flags) > len(items[j].Flags)
+		}
+		return items[i].QuestionID < items[j].QuestionID
+	})
+
+	return &output.ItemAnalysis{
+		ExamID:       input.ExamID,
+		MinResponses: input.MinResponses,
+		Items:        items,
+	}, nil
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/usecase/input"
+	"nearline/backend/internal/usecase/output"
+)
+
+func TestQuestionStats_PointBiserial(t *testing.T) {
+	stats := &domain.QuestionStats{}
+	now := time.Now()
+	for _, r := range []domain.QuestionResponse{
+		{QuestionID: "q1", SelectedOptions: []string{"a"}, Correct: true, RestScore: 1.0},
+		{QuestionID: "q1", SelectedOptions: []string{"a"}, Correct: true, RestScore: 0.8},
+		{QuestionID: "q1", SelectedOptions: []string{"b"}, Correct: false, RestScore: 0.2},
+		{QuestionID: "q1", SelectedOptions: nil, Correct: false, RestScore: 0.4},
+	} {
+		stats.AddResponse(r, now)
+	}
+
+	assert.Equal(t, 4, stats.ResponseCount)
+	assert.Equal(t, 1, stats.UnansweredCount)
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, stats.OptionCounts)
+	assert.Equal(t, 0.5, stats.PValue())
+
+	r, ok := stats.PointBiserial()
+	assert.True(t, ok)
+	assert.InDelta(t, 0.9487, r, 1e-4)
+
+	_, ok = (&domain.QuestionStats{ResponseCount: 3, CorrectCount: 3, SumRest: 1.5, SumRestSq: 1, SumRestCorrect: 1.5}).PointBiserial()
+	assert.False(t, ok, "全員正解の場合は識別力を算出できない")
+}
+
+func TestCompleteAttempt_RecordsQuestionResponses(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockStatsRepo := new(MockUserStatsRepository)
+	mockQuestionStatsRepo := new(MockQuestionStatsRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, mockStatsRepo, mockQuestionStatsRepo, newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+	ctx := context.Background()
+	userID := "user-1"
+	examID := "cloud-digital-leader"
+	attempt, _ := domain.NewAttempt("attempt-1", userID, examID, "set-1", domain.ModeExam, 3, time.Now())
+	attempt.Answers = map[string][]string{"q1": {"a"}, "q2": {"b"}}
+
+	options := []domain.AnswerOption{{ID: "a"}, {ID: "b"}}
+	questions := []domain.Question{
+		{ID: "q1", Options: options, CorrectAnswers: []string{"a"}},
+		{ID: "q2", Options: options, CorrectAnswers: []string{"a"}},
+		{ID: "q3", Options: options, CorrectAnswers: []string{"a"}},
+	}
+
+	mockUserRepo.On("Find", ctx, userID).Return(&domain.User{ID: userID, Role: domain.RoleFree}, nil)
+	mockAttemptRepo.On("Find", ctx, "attempt-1", userID).Return(attempt, nil)
+	mockAttemptRepo.On("Save", ctx, mock.AnythingOfType("domain.Attempt")).Return(nil)
+	mockQuestionRepo.On("FindByExamSetID", ctx, examID, "set-1").Return(questions, nil)
+	mockStatsRepo.On("Find", ctx, userID, examID).Return(nil, nil)
+	mockStatsRepo.On("Save", ctx, mock.AnythingOfType("domain.UserExamStats")).Return(nil)
+	mockQuestionStatsRepo.On("Record", ctx, []domain.QuestionResponse{
+		{QuestionID: "q1", ExamID: examID, ExamSetID: "set-1", Revision: 1, SelectedOptions: []string{"a"}, Correct: true, RestScore: 0},
+		{QuestionID: "q2", ExamID: examID, ExamSetID: "set-1", Revision: 1, SelectedOptions: []string{"b"}, Correct: false, RestScore: 0.5},
+		{QuestionID: "q3", ExamID: examID, ExamSetID: "set-1", Revision: 1, SelectedOptions: []string{}, Correct: false, RestScore: 0.5},
+	}).Return(nil)
+
+	in, _ := input.NewCompleteAttempt(userID, "attempt-1", nil)
+	_, err := usecase.CompleteAttempt(ctx, in)
+
+	assert.NoError(t, err)
+	mockQuestionStatsRepo.AssertExpectations(t)
+}
+
+func TestGetItemAnalysis_FlagsOutliers(t *testing.T) {
+	ctx := context.Background()
+	examID := "cloud-digital-leader"
+	options := []domain.AnswerOption{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockQuestionStatsRepo := new(MockQuestionStatsRepository)
+	mockQuestionRepo.On("FindByExamID", ctx, examID).Return([]domain.Question{
+		{ID: "q1", Options: options, CorrectAnswers: []string{"a"}},
+		{ID: "q2", Options: options, CorrectAnswers: []string{"a"}},
+		{ID: "q3", Options: options, CorrectAnswers: []string{"a"}},
+	}, nil)
+	mockQuestionStatsRepo.On("FindByExamID", ctx, examID).Return([]domain.QuestionStats{
+		// 誤答の選択肢 b が正解 a より多く選ばれ、成績下位者ほど正解している
+		{QuestionID: "q1", ResponseCount: 4, CorrectCount: 1, OptionCounts: map[string]int{"a": 1, "b": 3}, SumRest: 2, SumRestSq: 1.5, SumRestCorrect: 0},
+		// 特に問題のない問題
+		{QuestionID: "q2", ResponseCount: 4, CorrectCount: 2, OptionCounts: map[string]int{"a": 2, "b": 1, "c": 1}, SumRest: 2.4, SumRestSq: 1.84, SumRestCorrect: 1.8},
+	}, nil)
+
+	usecase := NewItemAnalysisUsecase(mockQuestionRepo, mockQuestionStatsRepo)
+
+	in, err := input.NewGetItemAnalysis(examID, 1, false)
+	assert.NoError(t, err)
+	analysis, err := usecase.GetItemAnalysis(ctx, in)
+
+	assert.NoError(t, err)
+	assert.Len(t, analysis.Items, 1)
+	assert.Equal(t, "q1", analysis.Items[0].QuestionID)
+	assert.Equal(t, []domain.ItemFlag{domain.ItemFlagTooHard, domain.ItemFlagNegativeDiscrimination, domain.ItemFlagDistractorOverKey}, analysis.Items[0].Flags)
+
+	in, _ = input.NewGetItemAnalysis(examID, 1, true)
+	analysis, err = usecase.GetItemAnalysis(ctx, in)
+	assert.NoError(t, err)
+	assert.Len(t, analysis.Items, 2, "回答のない q3 は含めない")
+}
+
+func TestGetItemAnalysis_CurrentRevisionOnly(t *testing.T) {
+	ctx := context.Background()
+	examID := "cloud-digital-leader"
+	options := []domain.AnswerOption{{ID: "a"}, {ID: "b"}}
+
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockQuestionStatsRepo := new(MockQuestionStatsRepository)
+	// q1 は正解を b に修正して版2になった
+	mockQuestionRepo.On("FindByExamID", ctx, examID).Return([]domain.Question{
+		{ID: "q1", Options: options, CorrectAnswers: []string{"b"}, Revision: 2},
+		{ID: "q2", Options: options, CorrectAnswers: []string{"a"}},
+	}, nil)
+	mockQuestionStatsRepo.On("FindByExamID", ctx, examID).Return([]domain.QuestionStats{
+		{QuestionID: "q1", ResponseCount: 4, CorrectCount: 1, OptionCounts: map[string]int{"a": 1, "b": 3}},
+		{QuestionID: "q1", Revision: 2, ResponseCount: 2, CorrectCount: 2, OptionCounts: map[string]int{"b": 2}},
+		{QuestionID: "q2", Revision: 1, ResponseCount: 3, CorrectCount: 2, OptionCounts: map[string]int{"a": 2, "b": 1}},
+	}, nil)
+
+	usecase := NewItemAnalysisUsecase(mockQuestionRepo, mockQuestionStatsRepo)
+
+	in, _ := input.NewGetItemAnalysis(examID, 1, true)
+	analysis, err := usecase.GetItemAnalysis(ctx, in)
+
+	assert.NoError(t, err)
+	counts := lo.SliceToMap(analysis.Items, func(item output.ItemAnalysisItem) (string, int) {
+		return item.QuestionID, item.ResponseCount
+	})
+	assert.Equal(t, map[string]int{"q1": 2, "q2": 3}, counts)
+}
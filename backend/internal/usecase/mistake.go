@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+	"nearline/backend/internal/usecase/input"
+	"nearline/backend/internal/usecase/output"
+	"nearline/backend/internal/util"
+)
+
+// MistakeUsecase は完了済みの受験の回答から導出する間違いノートを扱います。
+type MistakeUsecase interface {
+	ListMistakes(ctx context.Context, input *input.ListMistakes) (*output.MistakeList, error)
+}
+
+type mistakeUsecase struct {
+	aRepo  repository.AttemptRepository
+	qRepo  repository.QuestionRepository
+	policy AccessPolicy
+}
+
+func NewMistakeUsecase(aRepo repository.AttemptRepository, qRepo repository.QuestionRepository, policy AccessPolicy) MistakeUsecase {
+	return &mistakeUsecase{
+		aRepo:  aRepo,
+		qRepo:  qRepo,
+		policy: policy,
+	}
+}
+
+// ListMistakes はユーザーがこれまでに間違えた問題のうち、まだ連続正解で外れていないものを返します。
+func (u *mistakeUsecase) ListMistakes(ctx context.Context, input *input.ListMistakes) (*output.MistakeList, error) {
+	if err := u.policy.AuthorizeExam(ctx, input.UserID, input.ExamID); err != nil {
+		return nil, err
+	}
+
+	mistakes, questionsByID, err := findOpenMistakes(ctx, u.aRepo, u.qRepo, input.UserID, input.ExamID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &output.MistakeList{ExamID: input.ExamID, ClearStreak: domain.MistakeClearStreak, Items: []output.MistakeItem{}}
+	for _, m := range mistakes {
+		if input.Domain != "" && m.Domain != input.Domain {
+			continue
+		}
+		list.Items = append(list.Items, output.NewMistakeItem(m, questionsByID[m.QuestionID]))
+	}
+	return list, nil
+}
+
+// findOpenMistakes はユーザーの完了済みの受験をすべて走査し、間違いノートに残っている問題と、試験の問題をIDで引けるマップを返します。
+func findOpenMistakes(ctx context.Context, aRepo repository.AttemptRepository, qRepo repository.QuestionRepository, userID, examID string) ([]domain.Mistake, map[string]domain.Question, error) {
+	attempts, err := findCompletedAttempts(ctx, aRepo, userID, examID)
+	if err != nil {
+		return nil, nil, err
+	}
+	questions, err := qRepo.FindByExamID(ctx, examID)
+	if err != nil {
+		return nil, nil, err
+	}
+	questionsByID := lo.KeyBy(questions, func(q domain.Question) string { return q.ID })
+
+	// 受験ごとに出題した版の問題で正誤を判定する
+	resolver := newRevisionResolver(qRepo)
+	servedByAttempt := make(map[string]map[string]domain.Question, len(attempts))
+	for i := range attempts {
+		served, err := resolver.resolve(ctx, &attempts[i], questions)
+		if err != nil {
+			return nil, nil, err
+		}
+		servedByAttempt[attempts[i].ID] = lo.KeyBy(served, func(q domain.Question) string { return q.ID })
+	}
+
+	questionFor := func(a domain.Attempt, questionID string) (domain.Question, bool) {
+		q, ok := servedByAttempt[a.ID][questionID]
+		return q, ok
+	}
+	mistakes := domain.BuildMistakes(attempts, questionFor, func(q domain.Question, selected []string) bool {
+		return isCorrect(selected, q.CorrectAnswers)
+	})
+	return lo.Reject(mistakes, func(m domain.Mistake, _ int) bool { return m.IsCleared() }), questionsByID, nil
+}
+
+// buildMistakesSet は間違いノートに残っている問題から、最後に間違えた日時の新しい順に最大 n 問を選びます。
+func (u *attemptUsecase) buildMistakesSet(ctx context.Context, userID, examID string, n int) ([]domain.Question, error) {
+	mistakes, questionsByID, err := findOpenMistakes(ctx, u.aRepo, u.qRepo, userID, examID)
+	if err != nil {
+		return nil, err
+	}
+	if len(mistakes) == 0 {
+		return nil, errors.Wrap(domain.ErrFailedPrecondition, "間違いノートに問題がありません")
+	}
+
+	return util.Map(lo.Subset(mistakes, 0, uint(n)), func(m domain.Mistake) domain.Question {
+		return questionsByID[m.QuestionID]
+	}), nil
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+	"nearline/backend/internal/usecase/input"
+)
+
+func completedAttemptAt(id string, completedAt time.Time, answers map[string][]string) domain.Attempt {
+	return domain.Attempt{ID: id, UserID: "user123", ExamID: "cloud-digital-leader", Status: domain.StatusCompleted, CompletedAt: &completedAt, Answers: answers}
+}
+
+func TestListMistakes(t *testing.T) {
+	ctx := context.Background()
+	userID := "user123"
+	examID := "cloud-digital-leader"
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	questions := []domain.Question{
+		{ID: "q1", Domain: "Compute", CorrectAnswers: []string{"1"}},
+		{ID: "q2", Domain: "Compute", CorrectAnswers: []string{"1"}},
+		{ID: "q3", Domain: "Storage", CorrectAnswers: []string{"1"}},
+		{ID: "q4", Domain: "Storage", CorrectAnswers: []string{"1"}},
+	}
+	// 取得順は完了日時順とは限らない
+	attempts := []domain.Attempt{
+		completedAttemptAt("a3", base.AddDate(0, 0, 3), map[string][]string{"q1": {"1"}, "q2": {"1"}, "q3": {"2"}}),
+		completedAttemptAt("a1", base.AddDate(0, 0, 1), map[string][]string{"q1": {"2"}, "q2": {"2"}, "q4": {"1"}}),
+		completedAttemptAt("a2", base.AddDate(0, 0, 2), map[string][]string{"q1": {"1"}, "q2": {"2"}, "q3": {}, "deleted": {"2"}}),
+	}
+
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockUserRepo.On("Find", ctx, userID).Return(&domain.User{ID: userID, Role: domain.RoleFree}, nil)
+	mockAttemptRepo.On("FindByUser", ctx, repository.AttemptQuery{
+		UserID:   userID,
+		ExamID:   examID,
+		Statuses: []domain.AttemptStatus{domain.StatusCompleted},
+		OrderBy:  repository.AttemptOrderStartedAt,
+		Limit:    completedAttemptPageSize,
+	}).Return(attempts, "", nil)
+	mockQuestionRepo.On("FindByExamID", ctx, examID).Return(questions, nil)
+	usecase := NewMistakeUsecase(mockAttemptRepo, mockQuestionRepo, NewAccessPolicy(mockUserRepo))
+
+	t.Run("連続正解で外れた問題を除き、最後に間違えた日時の新しい順に返す", func(t *testing.T) {
+		in, _ := input.NewListMistakes(userID, examID, "")
+		list, err := usecase.ListMistakes(ctx, in)
+
+		assert.NoError(t, err)
+		if assert.Len(t, list.Items, 2) {
+			assert.Equal(t, "q3", list.Items[0].QuestionID)
+			assert.Equal(t, 1, list.Items[0].WrongCount, "未回答は間違いに数えない")
+
+			assert.Equal(t, "q2", list.Items[1].QuestionID)
+			assert.Equal(t, 2, list.Items[1].WrongCount)
+			assert.Equal(t, 1, list.Items[1].CorrectStreak)
+			assert.Equal(t, base.AddDate(0, 0, 2), list.Items[1].LastWrongAt)
+			assert.Equal(t, base.AddDate(0, 0, 3), list.Items[1].LastSeenAt)
+			assert.Equal(t, []string{"1"}, list.Items[1].Question.CorrectAnswers)
+		}
+	})
+
+	t.Run("分野で絞り込む", func(t *testing.T) {
+		in, _ := input.NewListMistakes(userID, examID, "Compute")
+		list, err := usecase.ListMistakes(ctx, in)
+
+		assert.NoError(t, err)
+		if assert.Len(t, list.Items, 1) {
+			assert.Equal(t, "q2", list.Items[0].QuestionID)
+		}
+	})
+}
+
+func TestStartAttempt_MistakesSet(t *testing.T) {
+	ctx := context.Background()
+	user := domain.NewUser("user123", "user@example.com", domain.ProviderGoogle)
+	examID := "cloud-digital-leader"
+	base := time.Now().AddDate(0, 0, -7)
+
+	questions := []domain.Question{
+		{ID: "q1", Domain: "Compute", CorrectAnswers: []string{"1"}},
+		{ID: "q2", Domain: "Compute", CorrectAnswers: []string{"1"}},
+		{ID: "q3", Domain: "Storage", CorrectAnswers: []string{"1"}},
+	}
+	attempts := []domain.Attempt{
+		completedAttemptAt("a1", base, map[string][]string{"q1": {"2"}, "q2": {"2"}, "q3": {"1"}}),
+		completedAttemptAt("a2", base.AddDate(0, 0, 1), map[string][]string{"q2": {"2"}}),
+	}
+
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockExamRepo := new(MockExamRepository)
+	usecase := NewAttemptUsecase(mockExamRepo, mockQuestionRepo, mockAttemptRepo, new(MockUserStatsRepository), newMockQuestionStatsRepository(), newMockReviewItemRepository(), new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+	mockUserRepo.On("Find", ctx, user.ID).Return(user, nil)
+	mockAttemptRepo.On("FindByUser", ctx, mock.MatchedBy(func(q repository.AttemptQuery) bool {
+		return q.ExamSetID == domain.MistakesExamSetID
+	})).Return([]domain.Attempt{}, "", nil)
+	mockAttemptRepo.On("FindByUser", ctx, mock.MatchedBy(func(q repository.AttemptQuery) bool {
+		return q.ExamSetID == ""
+	})).Return(attempts, "", nil)
+	mockExamRepo.On("Find", ctx, examID).Return(&domain.Exam{ID: examID, DurationMinutes: 90}, nil)
+	mockQuestionRepo.On("FindByExamID", ctx, examID).Return(questions, nil)
+	mockAttemptRepo.On("Save", ctx, mock.AnythingOfType("domain.Attempt")).Return(nil)
+
+	in, _ := input.NewCreateAttempt(user.ID, input.CreateAttemptRequest{
+		ExamID:    examID,
+		ExamSetID: domain.MistakesExamSetID,
+		Mode:      domain.ModeExam,
+	})
+	attempt, err := usecase.StartAttempt(ctx, in)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"q2", "q1"}, attempt.QuestionIDs)
+	assert.Equal(t, domain.ModePractice, attempt.Mode)
+	assert.Nil(t, attempt.Deadline)
+}
@@ -1,9 +1,94 @@
 package output
 
 import (
+	"math"
+	"time"
+
 	"nearline/backend/internal/domain"
 )
 
-func NewAttemptOutput(a *domain.Attempt) *domain.Attempt {
-	return a
+// Attempt は受験データに、残り時間と経過時間を付加したものです。
+type Attempt struct {
+	*domain.Attempt
+	RemainingSeconds *int `json:"remainingSeconds,omitempty"` // 制限時間がない場合、または終了済みの場合は省略
+	ElapsedSeconds   int  `json:"elapsedSeconds"`             // 中断中を除いた受験時間
+}
+
+func NewAttemptOutput(a *domain.Attempt, now time.Time) *Attempt {
+	out := &Attempt{
+		Attempt:        a,
+		ElapsedSeconds: int(a.ElapsedActiveTime(now).Seconds()),
+	}
+	if remaining, ok := a.RemainingTime(now); ok {
+		seconds := int(remaining.Seconds())
+		out.RemainingSeconds = &seconds
+	}
+	return out
+}
+
+// AttemptList は受験履歴の1ページ分です。
+type AttemptList struct {
+	Attempts   []*Attempt `json:"attempts"`
+	NextCursor string     `json:"nextCursor,omitempty"` // 続きがない場合は空
+}
+
+func NewAttemptList(attempts []domain.Attempt, nextCursor string, now time.Time) *AttemptList {
+	out := make([]*Attempt, 0, len(attempts))
+	for i := range attempts {
+		out = append(out, NewAttemptOutput(&attempts[i], now))
+	}
+	return &AttemptList{Attempts: out, NextCursor: nextCursor}
+}
+
+// QuestionResult は1問ごとの採点結果です。
+type QuestionResult string
+
+const (
+	ResultCorrect    QuestionResult = "correct"    // 正解
+	ResultIncorrect  QuestionResult = "incorrect"  // 不正解
+	ResultUnanswered QuestionResult = "unanswered" // 未回答
+)
+
+// AttemptReview は完了した受験の振り返り結果です。
+// 分野ごとの平均回答時間は Attempt.DomainScores に含まれます。
+type AttemptReview struct {
+	Attempt        *Attempt         `json:"attempt"`
+	Questions      []QuestionReview `json:"questions"`
+	AvgTimeSeconds float64          `json:"avgTimeSeconds"` // 1問あたりの平均回答時間(秒)
+}
+
+func NewAttemptReview(attempt *Attempt, questions []QuestionReview) *AttemptReview {
+	review := &AttemptReview{Attempt: attempt, Questions: questions}
+	if len(questions) > 0 {
+		total := 0
+		for _, q := range questions {
+			total += q.TimeSpentSeconds
+		}
+		review.AvgTimeSeconds = math.Round(float64(total)/float64(len(questions))*10) / 10
+	}
+	return review
+}
+
+// QuestionReview は1問ごとの回答と正解・解説の対比です。
+type QuestionReview struct {
+	QuestionWithAnswer
+	SelectedAnswers  []string       `json:"selectedAnswers"`
+	Result           QuestionResult `json:"result"`
+	Points           float64        `json:"points"`           // 受験時の採点方式による得点
+	TimeSpentSeconds int            `json:"timeSpentSeconds"` // 累計回答時間(秒)
+	Flagged          bool           `json:"flagged"`          // 見直しフラグを付けていたかどうか
+}
+
+func NewQuestionReview(q domain.Question, selected []string, result QuestionResult, points float64, timeSpentSeconds int, flagged bool) QuestionReview {
+	if selected == nil {
+		selected = []string{}
+	}
+	return QuestionReview{
+		QuestionWithAnswer: NewQuestionWithAnswer(q),
+		SelectedAnswers:    selected,
+		Result:             result,
+		Points:             points,
+		TimeSpentSeconds:   timeSpentSeconds,
+		Flagged:            flagged,
+	}
 }
@@ -0,0 +1,8 @@
+package output
+
+// FieldDiff は項目ごとの変更前後の値です。
+type FieldDiff struct {
+	Field  string `json:"field"` // 例: "TotalScore", "DomainStats.Compute", "CorrectAnswers"
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
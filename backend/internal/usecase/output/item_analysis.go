@@ -0,0 +1,55 @@
+package output
+
+import (
+	"math"
+
+	"nearline/backend/internal/domain"
+)
+
+// ItemAnalysis は試験の問題ごとの項目分析結果です。
+type ItemAnalysis struct {
+	ExamID       string             `json:"examId"`
+	MinResponses int                `json:"minResponses"`
+	Items        []ItemAnalysisItem `json:"items"` // 懸念点の多い順
+}
+
+// ItemAnalysisItem は1問分の項目分析結果です。
+type ItemAnalysisItem struct {
+	QuestionID      string            `json:"questionId"`
+	ExamSetID       string            `json:"examSetId"`
+	Domain          string            `json:"domain"`
+	QuestionType    string            `json:"questionType"`
+	CorrectAnswers  []string          `json:"correctAnswers"`
+	ResponseCount   int               `json:"responseCount"`
+	CorrectCount    int               `json:"correctCount"`
+	UnansweredCount int               `json:"unansweredCount"`
+	OptionCounts    map[string]int    `json:"optionCounts"`
+	PValue          float64           `json:"pValue"`                  // 正答率 (0-1)
+	PointBiserial   *float64          `json:"pointBiserial,omitempty"` // 識別力 (-1〜1)。算出できない場合は省略
+	Flags           []domain.ItemFlag `json:"flags"`
+}
+
+func NewItemAnalysisItem(q domain.Question, s *domain.QuestionStats, flags []domain.ItemFlag) ItemAnalysisItem {
+	item := ItemAnalysisItem{
+		QuestionID:      q.ID,
+		ExamSetID:       q.ExamSetID,
+		Domain:          q.Domain,
+		QuestionType:    q.QuestionType,
+		CorrectAnswers:  q.CorrectAnswers,
+		ResponseCount:   s.ResponseCount,
+		CorrectCount:    s.CorrectCount,
+		UnansweredCount: s.UnansweredCount,
+		OptionCounts:    s.OptionCounts,
+		PValue:          roundThousandth(s.PValue()),
+		Flags:           flags,
+	}
+	if r, ok := s.PointBiserial(); ok {
+		r = roundThousandth(r)
+		item.PointBiserial = &r
+	}
+	return item
+}
+
+func roundThousandth(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
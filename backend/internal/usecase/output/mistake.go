@@ -0,0 +1,23 @@
+package output
+
+import "nearline/backend/internal/domain"
+
+// MistakeList は間違いノートです。連続正解で外れた問題は含みません。
+type MistakeList struct {
+	ExamID      string        `json:"examId"`
+	ClearStreak int           `json:"clearStreak"` // 間違いノートから外れるのに必要な連続正解数
+	Items       []MistakeItem `json:"items"`       // 最後に間違えた日時の新しい順
+}
+
+// MistakeItem は間違いノートの1問分です。受験完了後の振り返りのため正解・解説を含みます。
+type MistakeItem struct {
+	domain.Mistake
+	Question QuestionWithAnswer `json:"question"`
+}
+
+func NewMistakeItem(m domain.Mistake, q domain.Question) MistakeItem {
+	return MistakeItem{
+		Mistake:  m,
+		Question: NewQuestionWithAnswer(q),
+	}
+}
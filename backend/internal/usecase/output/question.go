@@ -1,7 +1,91 @@
 package output
 
-import "nearline/backend/internal/domain"
+import (
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/util"
+)
 
-func NewQuestions(questions []domain.Question) []domain.Question {
-	return questions
+// Question は受験中に表示する問題です。
+// 正解・解説を含めると開発者ツールから答えが読めてしまうため、問題文と選択肢のみを返します。
+type Question struct {
+	ID           string           `json:"id"`
+	ExamID       string           `json:"examId"`
+	ExamSetID    string           `json:"examSetId"`
+	ExamCode     string           `json:"examCode"`
+	QuestionText string           `json:"question"`
+	QuestionType string           `json:"questionType"`
+	Options      []QuestionOption `json:"answerOptions"`
+	Domain       string           `json:"domain"`
+}
+
+// QuestionOption は受験中に表示する選択肢です。
+type QuestionOption struct {
+	ID   string `json:"id"`
+	Text string `json:"answer"`
+}
+
+// QuestionWithAnswer は正解と解説を含む問題です。
+// 受験完了後、または練習モードの受験でのみ返却します。
+type QuestionWithAnswer struct {
+	ID                 string                     `json:"id"`
+	ExamID             string                     `json:"examId"`
+	ExamSetID          string                     `json:"examSetId"`
+	ExamCode           string                     `json:"examCode"`
+	QuestionText       string                     `json:"question"`
+	QuestionType       string                     `json:"questionType"`
+	Options            []QuestionOptionWithAnswer `json:"answerOptions"`
+	CorrectAnswers     []string                   `json:"correctAnswers"`
+	OverallExplanation string                     `json:"overallExplanation"`
+	Domain             string                     `json:"domain"`
+	ImageURL           string                     `json:"imageUrl,omitempty"`
+	ReferenceURLs      []string                   `json:"referenceUrls,omitempty"`
+}
+
+// QuestionOptionWithAnswer は解説を含む選択肢です。
+type QuestionOptionWithAnswer struct {
+	ID          string `json:"id"`
+	Text        string `json:"answer"`
+	Explanation string `json:"explanation"`
+}
+
+func NewQuestion(q domain.Question) Question {
+	return Question{
+		ID:           q.ID,
+		ExamID:       q.ExamID,
+		ExamSetID:    q.ExamSetID,
+		ExamCode:     q.ExamCode,
+		QuestionText: q.QuestionText,
+		QuestionType: q.QuestionType,
+		Options: util.Map(q.Options, func(o domain.AnswerOption) QuestionOption {
+			return QuestionOption{ID: o.ID, Text: o.Text}
+		}),
+		Domain: q.Domain,
+	}
+}
+
+func NewQuestions(questions []domain.Question) []Question {
+	return util.Map(questions, NewQuestion)
+}
+
+func NewQuestionWithAnswer(q domain.Question) QuestionWithAnswer {
+	return QuestionWithAnswer{
+		ID:           q.ID,
+		ExamID:       q.ExamID,
+		ExamSetID:    q.ExamSetID,
+		ExamCode:     q.ExamCode,
+		QuestionText: q.QuestionText,
+		QuestionType: q.QuestionType,
+		Options: util.Map(q.Options, func(o domain.AnswerOption) QuestionOptionWithAnswer {
+			return QuestionOptionWithAnswer{ID: o.ID, Text: o.Text, Explanation: o.Explanation}
+		}),
+		CorrectAnswers:     q.CorrectAnswers,
+		OverallExplanation: q.OverallExplanation,
+		Domain:             q.Domain,
+		ImageURL:           q.ImageURL,
+		ReferenceURLs:      q.ReferenceURLs,
+	}
+}
+
+func NewQuestionsWithAnswers(questions []domain.Question) []QuestionWithAnswer {
+	return util.Map(questions, NewQuestionWithAnswer)
 }
@@ -0,0 +1,33 @@
+package output
+
+import (
+	"time"
+
+	"nearline/backend/internal/domain"
+)
+
+// QuestionRevisionHistory は問題の版の履歴です。
+type QuestionRevisionHistory struct {
+	QuestionID      string             `json:"questionId"`
+	CurrentRevision int                `json:"currentRevision"`
+	Revisions       []QuestionRevision `json:"revisions"` // 版番号の古い順
+}
+
+// QuestionRevision は問題の1つの版と、直前の版からの変更内容です。
+type QuestionRevision struct {
+	Revision  int             `json:"revision"`
+	CreatedAt time.Time       `json:"createdAt"`
+	CreatedBy string          `json:"createdBy,omitempty"`
+	Question  domain.Question `json:"question"`
+	Changes   []FieldDiff     `json:"changes"` // 初版の場合は空
+}
+
+func NewQuestionRevision(rev domain.QuestionRevision, changes []FieldDiff) QuestionRevision {
+	return QuestionRevision{
+		Revision:  rev.Revision,
+		CreatedAt: rev.CreatedAt,
+		CreatedBy: rev.CreatedBy,
+		Question:  rev.Question,
+		Changes:   changes,
+	}
+}
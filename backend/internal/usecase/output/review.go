@@ -0,0 +1,39 @@
+package output
+
+import (
+	"time"
+
+	"nearline/backend/internal/domain"
+)
+
+// ReviewQueue は復習予定日時を過ぎた問題の一覧です。
+type ReviewQueue struct {
+	ExamID string            `json:"examId"`
+	Items  []ReviewQueueItem `json:"items"` // 復習予定日時の古い順
+}
+
+// ReviewQueueItem は復習対象の1問です。回答前に表示するため正解・解説は含めません。
+type ReviewQueueItem struct {
+	Question    Question  `json:"question"`
+	Box         int       `json:"box"`
+	DueAt       time.Time `json:"dueAt"`
+	ReviewCount int       `json:"reviewCount"`
+	LapseCount  int       `json:"lapseCount"`
+}
+
+func NewReviewQueueItem(item domain.ReviewItem, q domain.Question) ReviewQueueItem {
+	return ReviewQueueItem{
+		Question:    NewQuestion(q),
+		Box:         item.Box,
+		DueAt:       item.DueAt,
+		ReviewCount: item.ReviewCount,
+		LapseCount:  item.LapseCount,
+	}
+}
+
+// ReviewResult は復習での回答結果と、更新後のスケジュールです。
+type ReviewResult struct {
+	Correct  bool               `json:"correct"`
+	Item     domain.ReviewItem  `json:"item"`
+	Question QuestionWithAnswer `json:"question"`
+}
@@ -1,7 +1,45 @@
 package output
 
-import "nearline/backend/internal/domain"
+import (
+	"time"
+
+	"nearline/backend/internal/domain"
+)
 
 func NewUserExamStats(stats *domain.UserExamStats) *domain.UserExamStats {
 	return stats
 }
+
+// ScoreTrend は試験の完了済みの受験の得点推移と、合格可能性の推定です。
+type ScoreTrend struct {
+	ExamID    string            `json:"examId"`
+	Points    []ScoreTrendPoint `json:"points"`              // 完了日時の古い順
+	Readiness *domain.Readiness `json:"readiness,omitempty"` // 推定に必要な受験が足りない場合は省略
+}
+
+// ScoreTrendPoint は1回の受験の得点です。
+type ScoreTrendPoint struct {
+	AttemptID             string               `json:"attemptId"`
+	ExamSetID             string               `json:"examSetId"`
+	Mode                  domain.AttemptMode   `json:"mode"`
+	CompletedAt           time.Time            `json:"completedAt"`
+	Percentage            float64              `json:"percentage"`
+	ScaledScore           float64              `json:"scaledScore"`
+	Passed                bool                 `json:"passed"`
+	CountsTowardReadiness bool                 `json:"countsTowardReadiness"` // 合格可能性の推定に使用した受験かどうか (推定していない場合はすべて false)
+	DomainScores          []domain.DomainScore `json:"domainScores"`
+}
+
+func NewScoreTrendPoint(a *domain.Attempt, countsTowardReadiness bool) ScoreTrendPoint {
+	return ScoreTrendPoint{
+		AttemptID:             a.ID,
+		ExamSetID:             a.ExamSetID,
+		Mode:                  a.Mode,
+		CompletedAt:           *a.CompletedAt,
+		Percentage:            a.Percentage,
+		ScaledScore:           a.ScaledScore,
+		Passed:                a.Passed,
+		CountsTowardReadiness: countsTowardReadiness,
+		DomainScores:          a.DomainScores,
+	}
+}
@@ -0,0 +1,17 @@
+package output
+
+// StatsRebuildResult は統計再集計の結果です。
+type StatsRebuildResult struct {
+	ExamID       string             `json:"examId"`
+	DryRun       bool               `json:"dryRun"`
+	ScannedUsers int                `json:"scannedUsers"` // 対象となったユーザー数
+	ChangedUsers int                `json:"changedUsers"` // 統計に差分があったユーザー数
+	Users        []StatsRebuildUser `json:"users"`        // 差分があったユーザーのみ
+}
+
+// StatsRebuildUser はユーザーごとの再集計結果です。
+type StatsRebuildUser struct {
+	UserID   string      `json:"userId"`
+	Attempts int         `json:"attempts"` // 再集計に使用した完了済みの受験数
+	Diffs    []FieldDiff `json:"diffs"`
+}
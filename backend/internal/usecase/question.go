@@ -3,33 +3,48 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
 
 	"nearline/backend/internal/domain"
 	"nearline/backend/internal/repository"
 	"nearline/backend/internal/usecase/input"
 	"nearline/backend/internal/usecase/output"
+	"nearline/backend/internal/util"
 )
 
 type QuestionUsecase interface {
 	UploadQuestions(ctx context.Context, req input.UploadQuestionsRequest) error
-	GetExamQuestions(ctx context.Context, input *input.GetExamQuestions) ([]domain.Question, error)
+	GetExamQuestions(ctx context.Context, input *input.GetExamQuestions) ([]output.Question, error)
+	GetQuestion(ctx context.Context, input *input.GetQuestion) (*domain.Question, error)
+	UpdateQuestion(ctx context.Context, input *input.UpdateQuestion) (*domain.Question, error)
+	DeleteQuestion(ctx context.Context, input *input.GetQuestion) error
+	GetQuestionRevisions(ctx context.Context, input *input.GetQuestion) (*output.QuestionRevisionHistory, error)
 }
 
 type questionUsecase struct {
-	qRepo repository.QuestionRepository
+	examRepo repository.ExamRepository
+	qRepo    repository.QuestionRepository
+	txRepo   repository.TransactionRepository
+	policy   AccessPolicy
 }
 
-func NewQuestionUsecase(qRepo repository.QuestionRepository) QuestionUsecase {
-	return &questionUsecase{qRepo: qRepo}
+func NewQuestionUsecase(examRepo repository.ExamRepository, qRepo repository.QuestionRepository, txRepo repository.TransactionRepository, policy AccessPolicy) QuestionUsecase {
+	return &questionUsecase{examRepo: examRepo, qRepo: qRepo, txRepo: txRepo, policy: policy}
 }
 
+// UploadQuestions は問題を一括で登録し、試験セットの末尾に追加します。
+// 同じIDの問題が既にある場合は、内容が変わるときのみ新しい版として上書きし、セット内の出題順は変更しません。
 func (u *questionUsecase) UploadQuestions(ctx context.Context, req input.UploadQuestionsRequest) error {
 	if len(req.Questions) == 0 {
 		return errors.Wrap(domain.ErrInvalidArgument, "問題が提供されていません")
 	}
+	if req.ExamID == "" || req.ExamSetID == "" {
+		return errors.Wrap(domain.ErrInvalidArgument, "ExamIDとExamSetIDは必須です")
+	}
 
 	var domainQuestions []domain.Question
 	now := time.Now()
@@ -69,17 +84,221 @@ func (u *questionUsecase) UploadQuestions(ctx context.Context, req input.UploadQ
 		domainQuestions = append(domainQuestions, *q)
 	}
 
-	if err := u.qRepo.BulkCreate(ctx, domainQuestions); err != nil {
+	examSet, err := findSyncedExamSet(ctx, u.examRepo, u.qRepo, req.ExamID, req.ExamSetID)
+	if err != nil {
+		return err
+	}
+	ids := util.Map(domainQuestions, func(q domain.Question) string { return q.ID })
+	examSet.AddQuestionIDs(ids...)
+
+	// 上書きする問題は UpdateQuestion と同様に新しい版として記録し、上書き前に出題された受験の採点を保つ
+	existing, err := u.qRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	existingByID := lo.KeyBy(existing, func(q domain.Question) string { return q.ID })
+	var revisions []domain.QuestionRevision
+	for i := range domainQuestions {
+		before, ok := existingByID[domainQuestions[i].ID]
+		if !ok {
+			continue
+		}
+		// 内容が変わらない問題は版を増やさない。版ごとの項目分析の統計が途切れないよう、既存の版のまま保存する
+		if len(diffQuestions(before, domainQuestions[i])) == 0 {
+			domainQuestions[i] = before
+			continue
+		}
+		// 版管理の導入前に作成された問題は初版が記録されていないため、上書き前の内容を初版として記録する
+		if before.Revision == 0 {
+			revisions = append(revisions, domain.NewQuestionRevision(before))
+		}
+		domainQuestions[i].Revision = before.CurrentRevision() + 1
+		domainQuestions[i].CreatedAt = before.CreatedAt
+		domainQuestions[i].UpdatedAt = now
+		revisions = append(revisions, domain.NewQuestionRevision(domainQuestions[i]))
+	}
+
+	return u.txRepo.Run(ctx, func(txCtx context.Context) error {
+		for _, revision := range revisions {
+			if err := u.qRepo.SaveRevision(txCtx, revision); err != nil {
+				return err
+			}
+		}
+		if err := u.qRepo.BulkCreate(txCtx, domainQuestions); err != nil {
+			return err
+		}
+		return u.examRepo.SaveSet(txCtx, *examSet)
+	})
+}
+
+func (u *questionUsecase) GetExamQuestions(ctx context.Context, input *input.GetExamQuestions) ([]output.Question, error) {
+	if err := u.policy.AuthorizeExam(ctx, input.UserID, input.ExamID); err != nil {
+		return nil, err
+	}
+
+	questions, err := u.qRepo.FindByExamSetID(ctx, input.ExamID, input.ExamSetID)
+	if err != nil {
+		return nil, err
+	}
+	examSet, err := u.examRepo.FindSet(ctx, input.ExamID, input.ExamSetID)
+	if err != nil {
+		return nil, err
+	}
+	return output.NewQuestions(examSet.SortQuestions(questions)), nil
+}
+
+// GetQuestion は管理画面向けに、正解・解説を含む問題を1件返します。
+func (u *questionUsecase) GetQuestion(ctx context.Context, input *input.GetQuestion) (*domain.Question, error) {
+	return u.qRepo.Find(ctx, input.QuestionID)
+}
+
+// UpdateQuestion は問題の指定された項目を更新します。
+// 更新後の内容を検証し、正解が選択肢と整合しない場合は保存せずに domain.ValidationError を返します。
+// 内容が変わる更新のたびに新しい版を記録するため、更新前に出題された受験は出題した版の内容で振り返り・再採点されます。
+func (u *questionUsecase) UpdateQuestion(ctx context.Context, input *input.UpdateQuestion) (*domain.Question, error) {
+	q, err := u.qRepo.Find(ctx, input.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	before := *q
+
+	if input.QuestionText != nil {
+		q.QuestionText = *input.QuestionText
+	}
+	if input.QuestionType != nil {
+		q.QuestionType = *input.QuestionType
+	}
+	if input.Options != nil {
+		q.Options = newAnswerOptions(*input.Options)
+	}
+	if input.CorrectAnswers != nil {
+		q.CorrectAnswers = *input.CorrectAnswers
+	}
+	if input.OverallExplanation != nil {
+		q.OverallExplanation = *input.OverallExplanation
+	}
+	if input.Domain != nil {
+		q.Domain = *input.Domain
+	}
+	if input.ImageURL != nil {
+		q.ImageURL = *input.ImageURL
+	}
+	if input.ReferenceURLs != nil {
+		q.ReferenceURLs = *input.ReferenceURLs
+	}
+
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	// 内容が変わらない更新では版を増やさず、版ごとの項目分析の統計を引き継ぐ
+	if len(diffQuestions(before, *q)) == 0 {
+		return q, nil
+	}
+
+	q.Revision = before.CurrentRevision() + 1
+	q.UpdatedAt = time.Now()
+	q.UpdatedBy = input.UpdatedBy
+
+	err = u.txRepo.Run(ctx, func(txCtx context.Context) error {
+		// 版管理の導入前に作成された問題は初版が記録されていないため、更新前の内容を初版として記録する
+		if before.Revision == 0 {
+			if err := u.qRepo.SaveRevision(txCtx, domain.NewQuestionRevision(before)); err != nil {
+				return err
+			}
+		}
+		if err := u.qRepo.SaveRevision(txCtx, domain.NewQuestionRevision(*q)); err != nil {
+			return err
+		}
+		return u.qRepo.Save(txCtx, *q)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// DeleteQuestion は問題を削除し、試験セットからも取り除きます。
+// 削除した問題は以後の受験・採点の対象から外れますが、完了済みの受験の採点結果と累積成績はそのまま残ります。
+// 削除前に出題された受験は、記録した版の内容で振り返り・再採点できます。
+func (u *questionUsecase) DeleteQuestion(ctx context.Context, input *input.GetQuestion) error {
+	q, err := u.qRepo.Find(ctx, input.QuestionID)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	var examSet *domain.ExamSet
+	if q.ExamSetID != "" {
+		examSet, err = u.examRepo.FindSet(ctx, q.ExamID, q.ExamSetID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+	}
+
+	return u.txRepo.Run(ctx, func(txCtx context.Context) error {
+		// 削除前に出題された受験を出題した版の内容で採点・振り返りできるよう、版が記録されていない問題は初版を記録する
+		if q.Revision == 0 {
+			if err := u.qRepo.SaveRevision(txCtx, domain.NewQuestionRevision(*q)); err != nil {
+				return err
+			}
+		}
+		if err := u.qRepo.Delete(txCtx, q.ID); err != nil {
+			return err
+		}
+		if examSet == nil {
+			return nil
+		}
+		examSet.RemoveQuestionIDs(q.ID)
+		return u.examRepo.SaveSet(txCtx, *examSet)
+	})
 }
 
-func (u *questionUsecase) GetExamQuestions(ctx context.Context, input *input.GetExamQuestions) ([]domain.Question, error) {
-	questions, err := u.qRepo.FindByExamSetID(ctx, input.ExamSetID)
+// GetQuestionRevisions は問題の版の履歴を、各版での変更内容とともに古い順に返します。
+// 一度も編集されていない問題は、現在の内容を初版として返します。
+func (u *questionUsecase) GetQuestionRevisions(ctx context.Context, input *input.GetQuestion) (*output.QuestionRevisionHistory, error) {
+	q, err := u.qRepo.Find(ctx, input.QuestionID)
 	if err != nil {
 		return nil, err
 	}
-	return output.NewQuestions(questions), nil
+	revisions, err := u.qRepo.FindRevisions(ctx, input.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		revisions = []domain.QuestionRevision{domain.NewQuestionRevision(*q)}
+	}
+
+	history := &output.QuestionRevisionHistory{
+		QuestionID:      q.ID,
+		CurrentRevision: q.CurrentRevision(),
+		Revisions:       make([]output.QuestionRevision, 0, len(revisions)),
+	}
+	for i, rev := range revisions {
+		changes := []output.FieldDiff{}
+		if i > 0 {
+			changes = diffQuestions(revisions[i-1].Question, rev.Question)
+		}
+		history.Revisions = append(history.Revisions, output.NewQuestionRevision(rev, changes))
+	}
+	return history, nil
+}
+
+// diffQuestions は2つの版の問題の内容の差分を返します。版番号や更新日時などのメタデータは比較しません。
+func diffQuestions(before, after domain.Question) []output.FieldDiff {
+	for _, q := range []*domain.Question{&before, &after} {
+		q.Revision = 0
+		q.CreatedAt = time.Time{}
+		q.UpdatedAt = time.Time{}
+		q.UpdatedBy = ""
+	}
+	diffs := diffValues("", reflect.ValueOf(before), reflect.ValueOf(after))
+	if diffs == nil {
+		return []output.FieldDiff{}
+	}
+	return diffs
+}
+
+func newAnswerOptions(options []input.OptionInput) []domain.AnswerOption {
+	return util.Map(options, func(o input.OptionInput) domain.AnswerOption {
+		return domain.AnswerOption{ID: o.ID, Text: o.Text, Explanation: o.Explanation}
+	})
 }
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+)
+
+// findAttemptQuestions は受験対象の問題を、受験開始時に出題した版の内容で返します。
+func findAttemptQuestions(ctx context.Context, qRepo repository.QuestionRepository, attempt *domain.Attempt) ([]domain.Question, error) {
+	questions, err := findLatestAttemptQuestions(ctx, qRepo, attempt)
+	if err != nil {
+		return nil, err
+	}
+	return newRevisionResolver(qRepo).resolve(ctx, attempt, questions)
+}
+
+// findLatestAttemptQuestions は受験対象の問題を現在の版の内容で返します。
+// 受験開始時に記録した問題を出題順に返し、記録がない受験 (出題順の記録の導入前の受験) は試験セットの現在の問題を返します。
+// 受験後に削除された問題は、出題した版の記録があればその内容で返します。
+func findLatestAttemptQuestions(ctx context.Context, qRepo repository.QuestionRepository, attempt *domain.Attempt) ([]domain.Question, error) {
+	if len(attempt.QuestionIDs) == 0 {
+		return qRepo.FindByExamSetID(ctx, attempt.ExamID, attempt.ExamSetID)
+	}
+
+	questions, err := qRepo.FindByIDs(ctx, attempt.QuestionIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := lo.KeyBy(questions, func(q domain.Question) string { return q.ID })
+
+	result := make([]domain.Question, 0, len(attempt.QuestionIDs))
+	for _, id := range attempt.QuestionIDs {
+		if q, ok := byID[id]; ok {
+			result = append(result, q)
+			continue
+		}
+		revision, ok := attempt.QuestionRevisions[id]
+		if !ok {
+			// 出題した版が記録されていない削除済みの問題は採点対象から除外する
+			continue
+		}
+		rev, err := qRepo.FindRevision(ctx, id, revision)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		result = append(result, rev.Question)
+	}
+	return result, nil
+}
+
+// revisionResolver は受験で出題した版の問題を取得します。
+// 同じ版を何度も取得しないよう、取得した版をキャッシュします。
+type revisionResolver struct {
+	qRepo repository.QuestionRepository
+	cache map[string]domain.Question
+}
+
+func newRevisionResolver(qRepo repository.QuestionRepository) *revisionResolver {
+	return &revisionResolver{qRepo: qRepo, cache: make(map[string]domain.Question)}
+}
+
+// resolve は questions (現在の版) を、attempt の受験開始時に出題した版の内容に置き換えて返します。
+// 出題した版が記録されていない受験 (版管理の導入前の受験) の問題は、現在の版をそのまま使用します。
+func (r *revisionResolver) resolve(ctx context.Context, attempt *domain.Attempt, questions []domain.Question) ([]domain.Question, error) {
+	if len(attempt.QuestionRevisions) == 0 {
+		return questions, nil
+	}
+
+	resolved := make([]domain.Question, 0, len(questions))
+	for _, q := range questions {
+		served, err := r.served(ctx, attempt, q)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, served)
+	}
+	return resolved, nil
+}
+
+// served は現在の版の問題 q を、attempt で出題した版の内容にして返します。
+func (r *revisionResolver) served(ctx context.Context, attempt *domain.Attempt, q domain.Question) (domain.Question, error) {
+	revision, ok := attempt.QuestionRevisions[q.ID]
+	if !ok || revision == q.CurrentRevision() {
+		return q, nil
+	}
+
+	key := fmt.Sprintf("%s@%d", q.ID, revision)
+	if cached, ok := r.cache[key]; ok {
+		return cached, nil
+	}
+
+	rev, err := r.qRepo.FindRevision(ctx, q.ID, revision)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			// 版の記録が失われている場合は現在の版で代替する
+			r.cache[key] = q
+			return q, nil
+		}
+		return domain.Question{}, err
+	}
+	r.cache[key] = rev.Question
+	return rev.Question, nil
+}
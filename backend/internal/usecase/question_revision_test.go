@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/usecase/input"
+	"nearline/backend/internal/usecase/output"
+)
+
+func TestUpdateQuestion_Revisions(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	options := []domain.AnswerOption{{ID: "a", Text: "A"}, {ID: "b", Text: "B"}}
+
+	t.Run("版管理の導入前の問題は、更新前の内容を初版として記録する", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewQuestionUsecase(new(MockExamRepository), mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+		mockQuestionRepo.On("Find", ctx, "q1").Return(&domain.Question{
+			ID: "q1", QuestionType: domain.QuestionTypeMultipleChoice, QuestionText: "旧", Options: options, CorrectAnswers: []string{"a"}, CreatedAt: createdAt,
+		}, nil)
+
+		var revisions []domain.QuestionRevision
+		mockQuestionRepo.On("SaveRevision", ctx, mock.MatchedBy(func(rev domain.QuestionRevision) bool {
+			revisions = append(revisions, rev)
+			return true
+		})).Return(nil)
+		mockQuestionRepo.On("Save", ctx, mock.Anything).Return(nil)
+
+		in, _ := input.NewUpdateQuestion("q1", "admin-1", input.UpdateQuestionRequest{CorrectAnswers: &[]string{"b"}})
+		updated, err := usecase.UpdateQuestion(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, updated.Revision)
+		if assert.Len(t, revisions, 2) {
+			assert.Equal(t, 1, revisions[0].Revision)
+			assert.Equal(t, []string{"a"}, revisions[0].Question.CorrectAnswers)
+			assert.Equal(t, createdAt, revisions[0].CreatedAt)
+			assert.Equal(t, 2, revisions[1].Revision)
+			assert.Equal(t, []string{"b"}, revisions[1].Question.CorrectAnswers)
+			assert.Equal(t, "admin-1", revisions[1].CreatedBy)
+		}
+	})
+
+	t.Run("版が記録済みの問題は、新しい版のみ記録する", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewQuestionUsecase(new(MockExamRepository), mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+		mockQuestionRepo.On("Find", ctx, "q1").Return(&domain.Question{
+			ID: "q1", QuestionType: domain.QuestionTypeMultipleChoice, QuestionText: "旧", Options: options, CorrectAnswers: []string{"a"}, Revision: 3,
+		}, nil)
+		mockQuestionRepo.On("SaveRevision", ctx, mock.MatchedBy(func(rev domain.QuestionRevision) bool {
+			return rev.Revision == 4
+		})).Return(nil).Once()
+		mockQuestionRepo.On("Save", ctx, mock.Anything).Return(nil)
+
+		in, _ := input.NewUpdateQuestion("q1", "admin-1", input.UpdateQuestionRequest{CorrectAnswers: &[]string{"b"}})
+		updated, err := usecase.UpdateQuestion(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, updated.Revision)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+
+	t.Run("内容が変わらない更新では版を増やさない", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewQuestionUsecase(new(MockExamRepository), mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+		updatedAt := createdAt.Add(time.Hour)
+		mockQuestionRepo.On("Find", ctx, "q1").Return(&domain.Question{
+			ID: "q1", QuestionType: domain.QuestionTypeMultipleChoice, QuestionText: "旧", Options: options, CorrectAnswers: []string{"a"}, Revision: 3, UpdatedAt: updatedAt,
+		}, nil)
+
+		for _, req := range []input.UpdateQuestionRequest{{}, {CorrectAnswers: &[]string{"a"}}} {
+			in, _ := input.NewUpdateQuestion("q1", "admin-1", req)
+			updated, err := usecase.UpdateQuestion(ctx, in)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 3, updated.Revision)
+			assert.Equal(t, updatedAt, updated.UpdatedAt)
+		}
+		mockQuestionRepo.AssertNotCalled(t, "SaveRevision", mock.Anything, mock.Anything)
+		mockQuestionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetQuestionRevisions(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(24 * time.Hour)
+	v1 := domain.Question{ID: "q1", QuestionText: "旧", CorrectAnswers: []string{"a"}, CreatedAt: createdAt}
+	v2 := v1
+	v2.CorrectAnswers = []string{"b"}
+	v2.Revision = 2
+	v2.UpdatedAt = updatedAt
+	v2.UpdatedBy = "admin-1"
+
+	t.Run("各版の直前の版からの変更内容を返す", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewQuestionUsecase(new(MockExamRepository), mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+		mockQuestionRepo.On("Find", ctx, "q1").Return(&v2, nil)
+		mockQuestionRepo.On("FindRevisions", ctx, "q1").Return([]domain.QuestionRevision{
+			domain.NewQuestionRevision(v1),
+			domain.NewQuestionRevision(v2),
+		}, nil)
+
+		in, _ := input.NewGetQuestion("q1")
+		history, err := usecase.GetQuestionRevisions(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, history.CurrentRevision)
+		if assert.Len(t, history.Revisions, 2) {
+			assert.Empty(t, history.Revisions[0].Changes)
+			assert.Equal(t, "admin-1", history.Revisions[1].CreatedBy)
+			assert.Equal(t, updatedAt, history.Revisions[1].CreatedAt)
+			// 版番号や更新日時は変更内容に含めない
+			assert.Equal(t, []output.FieldDiff{
+				{Field: "CorrectAnswers", Before: []string{"a"}, After: []string{"b"}},
+			}, history.Revisions[1].Changes)
+		}
+	})
+
+	t.Run("一度も編集されていない問題は現在の内容を初版として返す", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewQuestionUsecase(new(MockExamRepository), mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+		mockQuestionRepo.On("Find", ctx, "q1").Return(&v1, nil)
+		mockQuestionRepo.On("FindRevisions", ctx, "q1").Return([]domain.QuestionRevision{}, nil)
+
+		in, _ := input.NewGetQuestion("q1")
+		history, err := usecase.GetQuestionRevisions(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, history.CurrentRevision)
+		if assert.Len(t, history.Revisions, 1) {
+			assert.Equal(t, 1, history.Revisions[0].Revision)
+			assert.Equal(t, v1, history.Revisions[0].Question)
+		}
+	})
+}
+
+func TestFindAttemptQuestions_ServedRevision(t *testing.T) {
+	ctx := context.Background()
+	options := []domain.AnswerOption{{ID: "a"}, {ID: "b"}}
+	// q1 は受験後に正解が a から b に修正された
+	current := []domain.Question{
+		{ID: "q1", Options: options, CorrectAnswers: []string{"b"}, Revision: 2},
+		{ID: "q2", Options: options, CorrectAnswers: []string{"a"}},
+	}
+	served := domain.Question{ID: "q1", Options: options, CorrectAnswers: []string{"a"}, Revision: 1}
+
+	t.Run("出題した版の内容を返す", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "cdl", "set-1").Return(current, nil)
+		mockQuestionRepo.On("FindRevision", ctx, "q1", 1).Return(&domain.QuestionRevision{QuestionID: "q1", Revision: 1, Question: served}, nil)
+
+		attempt := &domain.Attempt{ExamID: "cdl", ExamSetID: "set-1", QuestionRevisions: map[string]int{"q1": 1, "q2": 1}}
+		questions, err := findAttemptQuestions(ctx, mockQuestionRepo, attempt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Question{served, current[1]}, questions)
+		mockQuestionRepo.AssertNumberOfCalls(t, "FindRevision", 1)
+	})
+
+	t.Run("版が記録されていない受験は現在の内容を返す", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "cdl", "set-1").Return(current, nil)
+
+		questions, err := findAttemptQuestions(ctx, mockQuestionRepo, &domain.Attempt{ExamID: "cdl", ExamSetID: "set-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, current, questions)
+		mockQuestionRepo.AssertNotCalled(t, "FindRevision", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("出題した版が見つからない場合は現在の内容で代替する", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "cdl", "set-1").Return(current, nil)
+		mockQuestionRepo.On("FindRevision", ctx, "q1", 1).Return(nil, errors.Wrap(domain.ErrNotFound, "問題の版が見つかりませんでした"))
+
+		attempt := &domain.Attempt{ExamID: "cdl", ExamSetID: "set-1", QuestionRevisions: map[string]int{"q1": 1, "q2": 1}}
+		questions, err := findAttemptQuestions(ctx, mockQuestionRepo, attempt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, current, questions)
+	})
+
+	t.Run("受験後に削除された問題は出題した版の内容で返す", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		deleted := domain.Question{ID: "q3", Options: options, CorrectAnswers: []string{"a"}, Revision: 1}
+		mockQuestionRepo.On("FindByIDs", ctx, []string{"q1", "q3", "q2"}).Return([]domain.Question{served, current[1]}, nil)
+		mockQuestionRepo.On("FindRevision", ctx, "q3", 1).Return(&domain.QuestionRevision{QuestionID: "q3", Revision: 1, Question: deleted}, nil)
+
+		attempt := &domain.Attempt{
+			ExamID:            "cdl",
+			ExamSetID:         "set-1",
+			QuestionIDs:       []string{"q1", "q3", "q2"},
+			QuestionRevisions: map[string]int{"q1": 1, "q2": 1, "q3": 1},
+		}
+		questions, err := findAttemptQuestions(ctx, mockQuestionRepo, attempt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Question{served, deleted, current[1]}, questions)
+	})
+
+	t.Run("出題した版が記録されていない削除済みの問題は除外する", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockQuestionRepo.On("FindByIDs", ctx, []string{"q2", "q3"}).Return([]domain.Question{current[1]}, nil)
+
+		attempt := &domain.Attempt{ExamID: "cdl", ExamSetID: "set-1", QuestionIDs: []string{"q2", "q3"}}
+		questions, err := findAttemptQuestions(ctx, mockQuestionRepo, attempt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Question{current[1]}, questions)
+		mockQuestionRepo.AssertNotCalled(t, "FindRevision", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/usecase/input"
+	"nearline/backend/internal/usecase/output"
+)
+
+func TestUpdateQuestion(t *testing.T) {
+	ctx := context.Background()
+	original := func() *domain.Question {
+		return &domain.Question{
+			ID:             "PCD_SET1_001",
+			ExamID:         "professional_cloud_developer",
+			ExamSetID:      "set1",
+			QuestionText:   "Cloud Run の説明として正しいものはどれか",
+			QuestionType:   domain.QuestionTypeMultipleChoice,
+			Options:        []domain.AnswerOption{{ID: "a", Text: "A"}, {ID: "b", Text: "B"}, {ID: "c", Text: "C"}},
+			CorrectAnswers: []string{"a"},
+			Domain:         "Compute",
+		}
+	}
+
+	t.Run("指定した項目のみ更新し、更新者を記録する", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewQuestionUsecase(new(MockExamRepository), mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+		mockQuestionRepo.On("Find", ctx, "PCD_SET1_001").Return(original(), nil)
+		mockQuestionRepo.On("SaveRevision", ctx, mock.Anything).Return(nil)
+
+		var saved domain.Question
+		mockQuestionRepo.On("Save", ctx, mock.MatchedBy(func(q domain.Question) bool {
+			saved = q
+			return true
+		})).Return(nil)
+
+		in, _ := input.NewUpdateQuestion("PCD_SET1_001", "admin-1", input.UpdateQuestionRequest{
+			QuestionText:   lo.ToPtr("Cloud Run の説明として正しいものはどれですか"),
+			CorrectAnswers: &[]string{"b"},
+		})
+		updated, err := usecase.UpdateQuestion(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Cloud Run の説明として正しいものはどれですか", saved.QuestionText)
+		assert.Equal(t, []string{"b"}, saved.CorrectAnswers)
+		assert.Equal(t, "Compute", saved.Domain)
+		assert.Equal(t, "admin-1", saved.UpdatedBy)
+		assert.False(t, saved.UpdatedAt.IsZero())
+		assert.Equal(t, saved, *updated)
+	})
+
+	t.Run("正解が選択肢と整合しない場合は保存しない", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewQuestionUsecase(new(MockExamRepository), mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+		mockQuestionRepo.On("Find", ctx, "PCD_SET1_001").Return(original(), nil)
+
+		// 正解の選択肢 a を削除し、単一選択の問題に正解を2つ指定する
+		in, _ := input.NewUpdateQuestion("PCD_SET1_001", "admin-1", input.UpdateQuestionRequest{
+			Options:        &[]input.OptionInput{{ID: "b", Text: "B"}, {ID: "b", Text: "B2"}},
+			CorrectAnswers: &[]string{"a", "b"},
+		})
+		_, err := usecase.UpdateQuestion(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+		var verr *domain.ValidationError
+		if assert.True(t, errors.As(err, &verr)) {
+			assert.ElementsMatch(t, []domain.FieldError{
+				{Field: "answerOptions[1].id", Message: "選択肢のIDが重複しています: b"},
+				{Field: "correctAnswers", Message: "単一選択の問題の正解は1つです"},
+				{Field: "correctAnswers", Message: "存在しない選択肢です: a"},
+			}, verr.Fields)
+		}
+		mockQuestionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("存在しない問題", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewQuestionUsecase(new(MockExamRepository), mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+		mockQuestionRepo.On("Find", ctx, "missing").Return(nil, errors.Wrap(domain.ErrNotFound, "問題が見つかりませんでした"))
+
+		in, _ := input.NewUpdateQuestion("missing", "admin-1", input.UpdateQuestionRequest{})
+		_, err := usecase.UpdateQuestion(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestGetExamQuestions(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: "user-1", Role: domain.RoleAdmin}
+
+	t.Run("セットIDが他の試験と同じでも指定した試験の問題のみ返す", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewQuestionUsecase(mockExamRepo, mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+		mockUserRepo.On("Find", ctx, user.ID).Return(user, nil)
+		mockExamRepo.On("FindSet", ctx, "pcd", "practice_exam_1").Return(&domain.ExamSet{ID: "practice_exam_1", ExamID: "pcd", QuestionIDs: []string{"PCD_PE1_001"}}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "cdl", "practice_exam_1").Return([]domain.Question{{ID: "CDL_PE1_001", ExamID: "cdl", ExamSetID: "practice_exam_1"}}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "pcd", "practice_exam_1").Return([]domain.Question{{ID: "PCD_PE1_001", ExamID: "pcd", ExamSetID: "practice_exam_1"}}, nil)
+
+		in, _ := input.NewGetExamQuestions(user.ID, "pcd", "practice_exam_1")
+		questions, err := usecase.GetExamQuestions(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"PCD_PE1_001"}, lo.Map(questions, func(q output.Question, _ int) string { return q.ID }))
+	})
+}
+
+func TestUploadQuestions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("既存の問題を上書きする場合は新しい版として記録する", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewQuestionUsecase(mockExamRepo, mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+
+		createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		existing := domain.Question{ID: "PCD_SET1_001", ExamID: "pcd", ExamSetID: "SET1", QuestionText: "Q1", CreatedAt: createdAt}
+		mockExamRepo.On("FindSet", ctx, "pcd", "SET1").Return(&domain.ExamSet{ID: "SET1", ExamID: "pcd", QuestionIDs: []string{"PCD_SET1_001"}}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "pcd", "SET1").Return([]domain.Question{existing}, nil)
+		mockQuestionRepo.On("FindByIDs", ctx, []string{"PCD_SET1_001", "PCD_SET1_002"}).Return([]domain.Question{existing}, nil)
+		mockExamRepo.On("SaveSet", ctx, mock.Anything).Return(nil)
+
+		var revisions []domain.QuestionRevision
+		mockQuestionRepo.On("SaveRevision", ctx, mock.MatchedBy(func(r domain.QuestionRevision) bool {
+			revisions = append(revisions, r)
+			return true
+		})).Return(nil)
+		var saved []domain.Question
+		mockQuestionRepo.On("BulkCreate", ctx, mock.MatchedBy(func(qs []domain.Question) bool {
+			saved = qs
+			return true
+		})).Return(nil)
+
+		err := usecase.UploadQuestions(ctx, input.UploadQuestionsRequest{
+			ExamID:    "pcd",
+			ExamSetID: "SET1",
+			ExamCode:  "PCD",
+			Questions: []input.QuestionInput{
+				{Index: 1, QuestionText: "Q1 (修正版)"},
+				{Index: 2, QuestionText: "Q2"},
+			},
+		})
+
+		assert.NoError(t, err)
+		if assert.Len(t, revisions, 2) {
+			// 版管理の導入前の内容を初版として記録してから、上書き後の内容を版2として記録する
+			assert.Equal(t, 1, revisions[0].Revision)
+			assert.Equal(t, "Q1", revisions[0].Question.QuestionText)
+			assert.Equal(t, 2, revisions[1].Revision)
+			assert.Equal(t, "Q1 (修正版)", revisions[1].Question.QuestionText)
+		}
+		if assert.Len(t, saved, 2) {
+			assert.Equal(t, 2, saved[0].Revision)
+			assert.Equal(t, createdAt, saved[0].CreatedAt)
+			assert.Equal(t, 0, saved[1].Revision)
+		}
+	})
+
+	t.Run("同じ内容を再アップロードした場合は版を増やさない", func(t *testing.T) {
+		mockExamRepo := new(MockExamRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		usecase := NewQuestionUsecase(mockExamRepo, mockQuestionRepo, new(MockTransactionRepository), NewAccessPolicy(new(MockUserRepository)))
+
+		createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		existing := domain.Question{ID: "PCD_SET1_001", ExamID: "pcd", ExamSetID: "SET1", ExamCode: "PCD", QuestionText: "Q1", ReferenceURLs: []string{}, CreatedAt: createdAt, Revision: 2, UpdatedAt: createdAt}
+		mockExamRepo.On("FindSet", ctx, "pcd", "SET1").Return(&domain.ExamSet{ID: "SET1", ExamID: "pcd", QuestionIDs: []string{"PCD_SET1_001"}}, nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, "pcd", "SET1").Return([]domain.Question{existing}, nil)
+		mockQuestionRepo.On("FindByIDs", ctx, []string{"PCD_SET1_001"}).Return([]domain.Question{existing}, nil)
+		mockExamRepo.On("SaveSet", ctx, mock.Anything).Return(nil)
+		var saved []domain.Question
+		mockQuestionRepo.On("BulkCreate", ctx, mock.MatchedBy(func(qs []domain.Question) bool {
+			saved = qs
+			return true
+		})).Return(nil)
+
+		err := usecase.UploadQuestions(ctx, input.UploadQuestionsRequest{
+			ExamID:    "pcd",
+			ExamSetID: "SET1",
+			ExamCode:  "PCD",
+			Questions: []input.QuestionInput{{Index: 1, QuestionText: "Q1"}},
+		})
+
+		assert.NoError(t, err)
+		mockQuestionRepo.AssertNotCalled(t, "SaveRevision", mock.Anything, mock.Anything)
+		assert.Equal(t, []domain.Question{existing}, saved)
+	})
+}
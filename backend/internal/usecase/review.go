@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+	"nearline/backend/internal/usecase/input"
+	"nearline/backend/internal/usecase/output"
+	"nearline/backend/internal/util"
+)
+
+// ReviewUsecase は間隔反復学習 (Leitner 方式) による復習を扱います。
+// 復習項目は受験の完了時に登録・更新され、受験とは別に1問ずつ回答することもできます。
+type ReviewUsecase interface {
+	GetReviewQueue(ctx context.Context, input *input.GetReviewQueue) (*output.ReviewQueue, error)
+	RecordReview(ctx context.Context, input *input.RecordReview) (*output.ReviewResult, error)
+}
+
+type reviewUsecase struct {
+	qRepo  repository.QuestionRepository
+	rRepo  repository.ReviewItemRepository
+	policy AccessPolicy
+}
+
+func NewReviewUsecase(qRepo repository.QuestionRepository, rRepo repository.ReviewItemRepository, policy AccessPolicy) ReviewUsecase {
+	return &reviewUsecase{
+		qRepo:  qRepo,
+		rRepo:  rRepo,
+		policy: policy,
+	}
+}
+
+// GetReviewQueue は復習予定日時を過ぎた問題を、予定日時の古い順に返します。
+// 削除された問題の復習項目は結果に含めません。
+func (u *reviewUsecase) GetReviewQueue(ctx context.Context, input *input.GetReviewQueue) (*output.ReviewQueue, error) {
+	if err := u.policy.AuthorizeExam(ctx, input.UserID, input.ExamID); err != nil {
+		return nil, err
+	}
+
+	items, err := u.rRepo.FindDue(ctx, input.UserID, input.ExamID, time.Now(), input.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := u.qRepo.FindByIDs(ctx, util.Map(items, func(item domain.ReviewItem) string { return item.QuestionID }))
+	if err != nil {
+		return nil, err
+	}
+	questionsByID := make(map[string]domain.Question, len(questions))
+	for _, q := range questions {
+		questionsByID[q.ID] = q
+	}
+
+	queue := &output.ReviewQueue{ExamID: input.ExamID, Items: []output.ReviewQueueItem{}}
+	for _, item := range items {
+		q, ok := questionsByID[item.QuestionID]
+		if !ok {
+			continue
+		}
+		queue.Items = append(queue.Items, output.NewReviewQueueItem(item, q))
+	}
+	return queue, nil
+}
+
+// RecordReview は復習での回答を採点し、結果に応じて次回の復習予定日時を更新します。
+// 復習予定日時より前の回答は、間隔反復のスケジュールが崩れるため受け付けません。
+func (u *reviewUsecase) RecordReview(ctx context.Context, input *input.RecordReview) (*output.ReviewResult, error) {
+	item, err := u.rRepo.Find(ctx, input.UserID, input.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.policy.AuthorizeExam(ctx, input.UserID, item.ExamID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !item.IsDue(now) {
+		return nil, errors.Wrap(domain.ErrFailedPrecondition, "この問題はまだ復習予定日時になっていません")
+	}
+
+	questions, err := u.qRepo.FindByIDs(ctx, []string{input.QuestionID})
+	if err != nil {
+		return nil, err
+	}
+	if len(questions) == 0 {
+		return nil, errors.Wrap(domain.ErrNotFound, "問題が見つかりませんでした")
+	}
+	q := questions[0]
+
+	if err := domain.ValidateAnswers(questions, map[string][]string{q.ID: input.SelectedAnswers}); err != nil {
+		return nil, err
+	}
+
+	correct := isCorrect(input.SelectedAnswers, q.CorrectAnswers)
+	item.RecordOutcome(correct, now)
+	if err := u.rRepo.Save(ctx, *item); err != nil {
+		return nil, err
+	}
+
+	return &output.ReviewResult{
+		Correct:  correct,
+		Item:     *item,
+		Question: output.NewQuestionWithAnswer(q),
+	}, nil
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/usecase/input"
+)
+
+func TestReviewItem_RecordOutcome(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	item, err := domain.NewReviewItem("user-1", domain.Question{ID: "q1"}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, item.Box)
+	assert.Equal(t, now.AddDate(0, 0, 1), item.DueAt)
+
+	item.RecordOutcome(true, now)
+	item.RecordOutcome(true, now)
+	assert.Equal(t, 3, item.Box)
+	assert.Equal(t, now.AddDate(0, 0, 7), item.DueAt)
+
+	for range 5 {
+		item.RecordOutcome(true, now)
+	}
+	assert.Equal(t, len(domain.LeitnerIntervals), item.Box, "最後の箱に留まる")
+
+	item.RecordOutcome(false, now)
+	assert.Equal(t, 1, item.Box)
+	assert.Equal(t, 1, item.LapseCount)
+	assert.Equal(t, 8, item.ReviewCount)
+	assert.False(t, item.IsDue(now))
+	assert.True(t, item.IsDue(now.AddDate(0, 0, 1)))
+}
+
+func TestCompleteAttempt_SchedulesReviews(t *testing.T) {
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockQuestionRepo := new(MockQuestionRepository)
+	mockStatsRepo := new(MockUserStatsRepository)
+	mockReviewRepo := new(MockReviewItemRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	usecase := NewAttemptUsecase(new(MockExamRepository), mockQuestionRepo, mockAttemptRepo, mockStatsRepo, newMockQuestionStatsRepository(), mockReviewRepo, new(MockTransactionRepository), NewAccessPolicy(mockUserRepo))
+
+	ctx := context.Background()
+	userID := "user-1"
+	examID := "cloud-digital-leader"
+	attempt, _ := domain.NewAttempt("attempt-1", userID, examID, "set-1", domain.ModePractice, 3, time.Now())
+	attempt.Answers = map[string][]string{"q1": {"a"}, "q2": {"b"}}
+
+	options := []domain.AnswerOption{{ID: "a"}, {ID: "b"}}
+	questions := []domain.Question{
+		{ID: "q1", ExamID: examID, ExamSetID: "set-1", Domain: "D1", Options: options, CorrectAnswers: []string{"a"}},
+		{ID: "q2", ExamID: examID, ExamSetID: "set-1", Domain: "D1", Options: options, CorrectAnswers: []string{"a"}},
+		{ID: "q3", ExamID: examID, ExamSetID: "set-1", Domain: "D2", Options: options, CorrectAnswers: []string{"a"}},
+	}
+	// q1 は以前に間違えて登録済み。今回正解したので箱2へ進む
+	existing := domain.ReviewItem{UserID: userID, QuestionID: "q1", ExamID: examID, Box: 1}
+
+	mockUserRepo.On("Find", ctx, userID).Return(&domain.User{ID: userID, Role: domain.RoleFree}, nil)
+	mockAttemptRepo.On("Find", ctx, "attempt-1", userID).Return(attempt, nil)
+	mockAttemptRepo.On("Save", ctx, mock.AnythingOfType("domain.Attempt")).Return(nil)
+	mockQuestionRepo.On("FindByExamSetID", ctx, examID, "set-1").Return(questions, nil)
+	mockStatsRepo.On("Find", ctx, userID, examID).Return(nil, nil)
+	mockStatsRepo.On("Save", ctx, mock.AnythingOfType("domain.UserExamStats")).Return(nil)
+	mockReviewRepo.On("FindByQuestionIDs", ctx, userID, []string{"q1", "q2", "q3"}).Return([]domain.ReviewItem{existing}, nil)
+
+	var saved []domain.ReviewItem
+	mockReviewRepo.On("SaveAll", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).([]domain.ReviewItem)
+	}).Return(nil)
+
+	in, _ := input.NewCompleteAttempt(userID, "attempt-1", nil)
+	_, err := usecase.CompleteAttempt(ctx, in)
+
+	assert.NoError(t, err)
+	if assert.Len(t, saved, 3) {
+		assert.Equal(t, "q1", saved[0].QuestionID)
+		assert.Equal(t, 2, saved[0].Box)
+		assert.Equal(t, "q2", saved[1].QuestionID)
+		assert.Equal(t, 1, saved[1].Box)
+		assert.Equal(t, "q3", saved[2].QuestionID, "未回答の問題も復習対象に登録する")
+		assert.Equal(t, "D2", saved[2].Domain)
+	}
+}
+
+func TestRecordReview(t *testing.T) {
+	ctx := context.Background()
+	userID := "user-1"
+	examID := "cloud-digital-leader"
+	question := domain.Question{ID: "q1", ExamID: examID, Options: []domain.AnswerOption{{ID: "a"}, {ID: "b"}}, CorrectAnswers: []string{"a"}}
+
+	setup := func() (ReviewUsecase, *MockReviewItemRepository) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockReviewRepo := new(MockReviewItemRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("Find", ctx, userID).Return(&domain.User{ID: userID, Role: domain.RoleFree}, nil)
+		mockQuestionRepo.On("FindByIDs", ctx, []string{"q1"}).Return([]domain.Question{question}, nil)
+		mockReviewRepo.On("Find", ctx, userID, "q1").Return(&domain.ReviewItem{UserID: userID, QuestionID: "q1", ExamID: examID, Box: 3}, nil)
+		return NewReviewUsecase(mockQuestionRepo, mockReviewRepo, NewAccessPolicy(mockUserRepo)), mockReviewRepo
+	}
+
+	t.Run("不正解の場合は箱1に戻る", func(t *testing.T) {
+		usecase, mockReviewRepo := setup()
+		mockReviewRepo.On("Save", ctx, mock.MatchedBy(func(item domain.ReviewItem) bool {
+			return item.Box == 1 && item.LapseCount == 1 && item.ReviewCount == 1
+		})).Return(nil)
+
+		in, _ := input.NewRecordReview(userID, "q1", []string{"b"})
+		result, err := usecase.RecordReview(ctx, in)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Correct)
+		assert.Equal(t, []string{"a"}, result.Question.CorrectAnswers)
+		mockReviewRepo.AssertExpectations(t)
+	})
+
+	t.Run("復習予定日時前の回答は保存せずにエラー", func(t *testing.T) {
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockReviewRepo := new(MockReviewItemRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("Find", ctx, userID).Return(&domain.User{ID: userID, Role: domain.RoleFree}, nil)
+		mockReviewRepo.On("Find", ctx, userID, "q1").Return(&domain.ReviewItem{UserID: userID, QuestionID: "q1", ExamID: examID, Box: 3, DueAt: time.Now().Add(time.Hour)}, nil)
+		usecase := NewReviewUsecase(mockQuestionRepo, mockReviewRepo, NewAccessPolicy(mockUserRepo))
+
+		in, _ := input.NewRecordReview(userID, "q1", []string{"a"})
+		result, err := usecase.RecordReview(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrFailedPrecondition)
+		assert.Nil(t, result, "解答を返さない")
+		mockQuestionRepo.AssertNotCalled(t, "FindByIDs", mock.Anything, mock.Anything)
+		mockReviewRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("存在しない選択肢は保存せずにエラー", func(t *testing.T) {
+		usecase, mockReviewRepo := setup()
+
+		in, _ := input.NewRecordReview(userID, "q1", []string{"z"})
+		_, err := usecase.RecordReview(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+		mockReviewRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
+	"nearline/backend/internal/domain"
+)
+
+// Scorer は1問分の回答に対する得点を計算します。
+// 満点は1点で、未回答は採点方式にかかわらず0点です。
+type Scorer interface {
+	Score(q domain.Question, selected []string) float64
+}
+
+// NewScorer は採点方式に対応する Scorer を返します。
+// 採点方式が未設定の受験 (採点方式の導入前に作成されたもの) は domain.DefaultScoringStrategy で採点します。
+func NewScorer(strategy domain.ScoringStrategy) (Scorer, error) {
+	switch strategy {
+	case "", domain.ScoringAllOrNothing:
+		return allOrNothingScorer{}, nil
+	case domain.ScoringPartialCredit:
+		return partialCreditScorer{}, nil
+	case domain.ScoringNegativeMarking:
+		return negativeMarkingScorer{penalty: domain.NegativeMarkingPenalty}, nil
+	default:
+		return nil, errors.Wrapf(domain.ErrInvalidArgument, "不正な採点方式です: %s", strategy)
+	}
+}
+
+// allOrNothingScorer は正解と完全に一致した場合のみ1点とします。
+type allOrNothingScorer struct{}
+
+func (allOrNothingScorer) Score(q domain.Question, selected []string) float64 {
+	if isCorrect(selected, q.CorrectAnswers) {
+		return 1
+	}
+	return 0
+}
+
+// partialCreditScorer は複数選択問題について、正解の選択肢を選んだ割合から誤った選択肢の割合を差し引いて部分点とします。
+// 全選択による部分点の獲得を防ぐため、誤った選択肢1つにつき正解1つ分を減点し、0点を下限とします。
+// 単一選択問題は完全一致のみ1点です。
+type partialCreditScorer struct{}
+
+func (partialCreditScorer) Score(q domain.Question, selected []string) float64 {
+	if q.QuestionType != domain.QuestionTypeMultiSelect || len(q.CorrectAnswers) == 0 {
+		return allOrNothingScorer{}.Score(q, selected)
+	}
+
+	picked := lo.Uniq(selected)
+	hits := len(lo.Intersect(picked, q.CorrectAnswers))
+	misses := len(picked) - hits
+
+	return max(float64(hits-misses)/float64(len(lo.Uniq(q.CorrectAnswers))), 0)
+}
+
+// negativeMarkingScorer は正解を1点、不正解を -penalty 点とします。未回答は0点です。
+type negativeMarkingScorer struct {
+	penalty float64
+}
+
+func (s negativeMarkingScorer) Score(q domain.Question, selected []string) float64 {
+	if len(selected) == 0 {
+		return 0
+	}
+	if isCorrect(selected, q.CorrectAnswers) {
+		return 1
+	}
+	return -s.penalty
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"slices"
 
 	"nearline/backend/internal/domain"
 	"nearline/backend/internal/repository"
@@ -11,14 +12,16 @@ import (
 
 type StatsUsecase interface {
 	GetUserExamStats(ctx context.Context, input *input.GetUserExamStats) (*domain.UserExamStats, error)
+	GetScoreTrend(ctx context.Context, input *input.GetUserExamStats) (*output.ScoreTrend, error)
 }
 
 type statsUsecase struct {
 	sRepo repository.UserStatsRepository
+	aRepo repository.AttemptRepository
 }
 
-func NewStatsUsecase(sRepo repository.UserStatsRepository) StatsUsecase {
-	return &statsUsecase{sRepo: sRepo}
+func NewStatsUsecase(sRepo repository.UserStatsRepository, aRepo repository.AttemptRepository) StatsUsecase {
+	return &statsUsecase{sRepo: sRepo, aRepo: aRepo}
 }
 
 func (u *statsUsecase) GetUserExamStats(ctx context.Context, input *input.GetUserExamStats) (*domain.UserExamStats, error) {
@@ -38,3 +41,28 @@ func (u *statsUsecase) GetUserExamStats(ctx context.Context, input *input.GetUse
 
 	return output.NewUserExamStats(stats), nil
 }
+
+// GetScoreTrend は完了済みの受験の得点推移(全体・分野別)と、直近の受験から推定した合格可能性を返します。
+func (u *statsUsecase) GetScoreTrend(ctx context.Context, input *input.GetUserExamStats) (*output.ScoreTrend, error) {
+	attempts, err := findCompletedAttempts(ctx, u.aRepo, input.UserID, input.ExamID)
+	if err != nil {
+		return nil, err
+	}
+	attempts = slices.DeleteFunc(attempts, func(a domain.Attempt) bool { return a.CompletedAt == nil })
+	slices.SortFunc(attempts, func(a, b domain.Attempt) int {
+		return a.CompletedAt.Compare(*b.CompletedAt)
+	})
+
+	trend := &output.ScoreTrend{ExamID: input.ExamID, Points: make([]output.ScoreTrendPoint, 0, len(attempts))}
+	used := map[string]bool{}
+	if readiness, ok := domain.EstimateReadiness(attempts); ok {
+		trend.Readiness = readiness
+		for _, a := range domain.ReadinessAttempts(attempts) {
+			used[a.ID] = true
+		}
+	}
+	for i := range attempts {
+		trend.Points = append(trend.Points, output.NewScoreTrendPoint(&attempts[i], used[attempts[i].ID]))
+	}
+	return trend, nil
+}
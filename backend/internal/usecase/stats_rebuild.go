@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/repository"
+	"nearline/backend/internal/usecase/input"
+	"nearline/backend/internal/usecase/output"
+	"nearline/backend/internal/util"
+)
+
+// completedAttemptPageSize は完了済みの受験履歴をすべて取得する際の1ページあたりの件数です。
+const completedAttemptPageSize = 100
+
+// statsRebuildMaxTries は再集計中に受験が完了した場合に、1ユーザーの再集計を試行する最大回数です。
+const statsRebuildMaxTries = 3
+
+type StatsRebuildUsecase interface {
+	// RebuildStats は完了済みの受験履歴からユーザーの試験ごとの累積成績を再計算します。
+	// 採点ロジックの変更を反映するため、受験は出題した版の問題データで再採点されます (受験データ自体は更新しません)。
+	// 出題した版が記録されていない受験は、現在の問題データで再採点されます。
+	RebuildStats(ctx context.Context, input *input.RebuildStats) (*output.StatsRebuildResult, error)
+}
+
+type statsRebuildUsecase struct {
+	userRepo repository.UserRepository
+	aRepo    repository.AttemptRepository
+	qRepo    repository.QuestionRepository
+	sRepo    repository.UserStatsRepository
+	txRepo   repository.TransactionRepository
+}
+
+func NewStatsRebuildUsecase(
+	userRepo repository.UserRepository,
+	aRepo repository.AttemptRepository,
+	qRepo repository.QuestionRepository,
+	sRepo repository.UserStatsRepository,
+	txRepo repository.TransactionRepository,
+) StatsRebuildUsecase {
+	return &statsRebuildUsecase{
+		userRepo: userRepo,
+		aRepo:    aRepo,
+		qRepo:    qRepo,
+		sRepo:    sRepo,
+		txRepo:   txRepo,
+	}
+}
+
+func (u *statsRebuildUsecase) RebuildStats(ctx context.Context, input *input.RebuildStats) (*output.StatsRebuildResult, error) {
+	userIDs := []string{input.UserID}
+	if input.UserID == "" {
+		users, err := u.userRepo.FindAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = util.Map(users, func(user domain.User) string {
+			return user.ID
+		})
+	}
+
+	result := &output.StatsRebuildResult{
+		ExamID: input.ExamID,
+		DryRun: input.DryRun,
+		Users:  []output.StatsRebuildUser{},
+	}
+	questionsBySet := make(map[string][]domain.Question)
+	resolver := newRevisionResolver(u.qRepo)
+
+	for _, userID := range userIDs {
+		var entry *output.StatsRebuildUser
+		var err error
+		for range statsRebuildMaxTries {
+			entry, err = u.rebuildUser(ctx, userID, input.ExamID, input.DryRun, questionsBySet, resolver)
+			if !errors.Is(err, domain.ErrFailedPrecondition) {
+				break
+			}
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "ユーザー %s の統計の再集計に失敗しました", userID)
+		}
+		result.ScannedUsers++
+		if entry != nil {
+			result.ChangedUsers++
+			result.Users = append(result.Users, *entry)
+		}
+	}
+
+	return result, nil
+}
+
+// rebuildUser は1ユーザー分の統計を再計算し、差分がある場合のみ結果を返します。
+// dryRun でない場合は再計算した統計を保存します。
+// 再計算中に受験が完了して統計が更新された場合は、その受験の反映を上書きしないよう保存せずに domain.ErrFailedPrecondition を返します。
+func (u *statsRebuildUsecase) rebuildUser(ctx context.Context, userID, examID string, dryRun bool, questionsBySet map[string][]domain.Question, resolver *revisionResolver) (*output.StatsRebuildUser, error) {
+	// 統計を受験履歴より先に読み取り、受験履歴の取得後に完了した受験を保存時の読み直しで検出できるようにする
+	current, err := u.sRepo.Find(ctx, userID, examID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := findCompletedAttempts(ctx, u.aRepo, userID, examID)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil && len(attempts) == 0 {
+		return nil, nil
+	}
+	before := current
+	if before == nil {
+		if before, err = domain.NewUserExamStats(userID, examID); err != nil {
+			return nil, err
+		}
+	}
+
+	after, err := domain.NewUserExamStats(userID, examID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range attempts {
+		attempt := &attempts[i]
+		// 出題した問題が同じ受験では取得結果を共有できるため、試験セットと出題順の問題IDの組み合わせでキャッシュする
+		key := attempt.ExamSetID + ":" + strings.Join(attempt.QuestionIDs, ",")
+		questions, ok := questionsBySet[key]
+		if !ok {
+			if questions, err = findLatestAttemptQuestions(ctx, u.qRepo, attempt); err != nil {
+				return nil, err
+			}
+			// 個別に生成したセットは受験ごとに問題が異なるためキャッシュしない
+			if !attempt.IsGenerated() {
+				questionsBySet[key] = questions
+			}
+		}
+		// 問題セットが削除されている場合は再採点できないため、記録済みの採点結果をそのまま使用する
+		if len(questions) > 0 {
+			served, err := resolver.resolve(ctx, attempt, questions)
+			if err != nil {
+				return nil, err
+			}
+			if err := scoreAttempt(attempt, served); err != nil {
+				return nil, err
+			}
+		}
+		after.AddAttempt(attempt)
+	}
+
+	diffs := diffValues("", reflect.ValueOf(*before), reflect.ValueOf(*after))
+	if len(diffs) == 0 {
+		return nil, nil
+	}
+
+	if !dryRun {
+		err := u.txRepo.Run(ctx, func(txCtx context.Context) error {
+			latest, err := u.sRepo.Find(txCtx, userID, examID)
+			if err != nil {
+				return err
+			}
+			if countsDifferentAttempts(current, latest) {
+				return errors.Wrap(domain.ErrFailedPrecondition, "再集計中に受験が完了したため統計を保存しませんでした")
+			}
+			return u.sRepo.Save(txCtx, *after)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &output.StatsRebuildUser{
+		UserID:   userID,
+		Attempts: len(attempts),
+		Diffs:    diffs,
+	}, nil
+}
+
+// countsDifferentAttempts は2つの時点の統計が、異なる受験を集計しているかどうかを返します。
+// 受験が完了するたびに受験回数と最終受験日時が更新されるため、これらが一致する場合は同じ受験を集計しているとみなします。
+func countsDifferentAttempts(before, after *domain.UserExamStats) bool {
+	if before == nil || after == nil {
+		return before != after
+	}
+	return before.TotalAttempts != after.TotalAttempts || !before.LastTakenAt.Equal(after.LastTakenAt)
+}
+
+// findCompletedAttempts はユーザーの指定された試験の完了済みの受験をすべて取得します。
+func findCompletedAttempts(ctx context.Context, aRepo repository.AttemptRepository, userID, examID string) ([]domain.Attempt, error) {
+	var attempts []domain.Attempt
+	cursor := ""
+	for {
+		page, next, err := aRepo.FindByUser(ctx, repository.AttemptQuery{
+			UserID:   userID,
+			ExamID:   examID,
+			Statuses: []domain.AttemptStatus{domain.StatusCompleted},
+			OrderBy:  repository.AttemptOrderStartedAt,
+			Limit:    completedAttemptPageSize,
+			Cursor:   cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, page...)
+		if next == "" {
+			return attempts, nil
+		}
+		cursor = next
+	}
+}
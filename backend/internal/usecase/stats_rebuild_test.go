@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/usecase/input"
+	"nearline/backend/internal/usecase/output"
+)
+
+func TestRebuildStats(t *testing.T) {
+	ctx := context.Background()
+	userID := "user-1"
+	examID := "cloud-digital-leader"
+	completedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	options := []domain.AnswerOption{{ID: "a"}, {ID: "b"}}
+	questions := []domain.Question{
+		{ID: "q1", Domain: "Compute", Options: options, CorrectAnswers: []string{"a"}},
+		{ID: "q2", Domain: "Security", Options: options, CorrectAnswers: []string{"a"}},
+	}
+	attempt := domain.Attempt{
+		ID:          "attempt-1",
+		UserID:      userID,
+		ExamID:      examID,
+		ExamSetID:   "set-1",
+		Status:      domain.StatusCompleted,
+		Answers:     map[string][]string{"q1": {"a"}, "q2": {"b"}},
+		CompletedAt: &completedAt,
+	}
+
+	// 旧採点ロジックで全問を正解扱いにしてしまった統計
+	corrupted, _ := domain.NewUserExamStats(userID, examID)
+	corrupted.TotalAttempts = 1
+	corrupted.TotalScore = 2
+	corrupted.TotalPoints = 1
+	corrupted.TotalQuestionsAnswered = 2
+	corrupted.BestScore = 50
+	corrupted.LastTakenAt = completedAt
+	corrupted.DomainStats["Compute"] = domain.DomainScore{DomainName: "Compute", CorrectCount: 1, TotalCount: 1, AccuracyRate: 100, Points: 1, ScoreRate: 100}
+	corrupted.DomainStats["Security"] = domain.DomainScore{DomainName: "Security", CorrectCount: 1, TotalCount: 1, AccuracyRate: 100, Points: 0, ScoreRate: 0}
+
+	setup := func() (StatsRebuildUsecase, *MockUserStatsRepository) {
+		mockAttemptRepo := new(MockAttemptRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockStatsRepo := new(MockUserStatsRepository)
+		mockUserRepo := new(MockUserRepository)
+
+		mockUserRepo.On("FindAll", ctx).Return([]domain.User{{ID: userID}}, nil)
+		mockAttemptRepo.On("FindByUser", ctx, mock.Anything).Return([]domain.Attempt{attempt}, "", nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, examID, "set-1").Return(questions, nil)
+		mockStatsRepo.On("Find", ctx, userID, examID).Return(corrupted, nil)
+		mockStatsRepo.On("Save", ctx, mock.AnythingOfType("domain.UserExamStats")).Return(nil)
+
+		return NewStatsRebuildUsecase(mockUserRepo, mockAttemptRepo, mockQuestionRepo, mockStatsRepo, new(MockTransactionRepository)), mockStatsRepo
+	}
+
+	t.Run("dry-run は差分のみ返し保存しない", func(t *testing.T) {
+		usecase, mockStatsRepo := setup()
+		in, _ := input.NewRebuildStats(examID, "", true)
+
+		result, err := usecase.RebuildStats(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.ScannedUsers)
+		assert.Equal(t, 1, result.ChangedUsers)
+		assert.Equal(t, []output.FieldDiff{
+			{Field: "TotalScore", Before: 2, After: 1},
+			{Field: "DomainStats.Security.CorrectCount", Before: 1, After: 0},
+			{Field: "DomainStats.Security.AccuracyRate", Before: 100, After: 0},
+		}, result.Users[0].Diffs)
+		mockStatsRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dry-run でない場合は再計算した統計を保存する", func(t *testing.T) {
+		usecase, mockStatsRepo := setup()
+		in, _ := input.NewRebuildStats(examID, userID, false)
+
+		_, err := usecase.RebuildStats(ctx, in)
+
+		assert.NoError(t, err)
+		mockStatsRepo.AssertCalled(t, "Save", ctx, mock.MatchedBy(func(s domain.UserExamStats) bool {
+			return s.TotalScore == 1 && s.DomainStats["Security"].CorrectCount == 0
+		}))
+	})
+
+	t.Run("再集計中に受験が完了した場合は再集計をやり直す", func(t *testing.T) {
+		mockAttemptRepo := new(MockAttemptRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockStatsRepo := new(MockUserStatsRepository)
+		usecase := NewStatsRebuildUsecase(new(MockUserRepository), mockAttemptRepo, mockQuestionRepo, mockStatsRepo, new(MockTransactionRepository))
+
+		// 1回目の受験履歴の取得後に attempt-2 が完了し、統計に反映された
+		latestAt := completedAt.Add(time.Hour)
+		attempt2 := attempt
+		attempt2.ID = "attempt-2"
+		attempt2.CompletedAt = &latestAt
+		updated := *corrupted
+		updated.TotalAttempts = 2
+		updated.LastTakenAt = latestAt
+		mockStatsRepo.On("Find", ctx, userID, examID).Return(corrupted, nil).Once()
+		mockStatsRepo.On("Find", ctx, userID, examID).Return(&updated, nil)
+		mockAttemptRepo.On("FindByUser", ctx, mock.Anything).Return([]domain.Attempt{attempt}, "", nil).Once()
+		mockAttemptRepo.On("FindByUser", ctx, mock.Anything).Return([]domain.Attempt{attempt, attempt2}, "", nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, examID, "set-1").Return(questions, nil)
+		mockStatsRepo.On("Save", ctx, mock.AnythingOfType("domain.UserExamStats")).Return(nil)
+
+		in, _ := input.NewRebuildStats(examID, userID, false)
+		result, err := usecase.RebuildStats(ctx, in)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Users[0].Attempts)
+		mockStatsRepo.AssertNumberOfCalls(t, "Save", 1)
+		mockStatsRepo.AssertCalled(t, "Save", ctx, mock.MatchedBy(func(s domain.UserExamStats) bool {
+			return s.TotalAttempts == 2 && latestAt.Equal(s.LastTakenAt)
+		}))
+	})
+
+	t.Run("受験の完了が続く場合は保存せずにエラー", func(t *testing.T) {
+		mockAttemptRepo := new(MockAttemptRepository)
+		mockQuestionRepo := new(MockQuestionRepository)
+		mockStatsRepo := new(MockUserStatsRepository)
+		usecase := NewStatsRebuildUsecase(new(MockUserRepository), mockAttemptRepo, mockQuestionRepo, mockStatsRepo, new(MockTransactionRepository))
+
+		// 統計を読み取るたびに受験回数が増えている
+		for i := range 2 * statsRebuildMaxTries {
+			stats := *corrupted
+			stats.TotalAttempts = i + 1
+			mockStatsRepo.On("Find", ctx, userID, examID).Return(&stats, nil).Once()
+		}
+		mockAttemptRepo.On("FindByUser", ctx, mock.Anything).Return([]domain.Attempt{attempt}, "", nil)
+		mockQuestionRepo.On("FindByExamSetID", ctx, examID, "set-1").Return(questions, nil)
+
+		in, _ := input.NewRebuildStats(examID, userID, false)
+		_, err := usecase.RebuildStats(ctx, in)
+
+		assert.ErrorIs(t, err, domain.ErrFailedPrecondition)
+		mockAttemptRepo.AssertNumberOfCalls(t, "FindByUser", statsRebuildMaxTries)
+		mockStatsRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"nearline/backend/internal/domain"
+	"nearline/backend/internal/usecase/input"
+	"nearline/backend/internal/usecase/output"
+)
+
+func scoredAttempt(id string, mode domain.AttemptMode, completedAt time.Time, scaledScore float64) domain.Attempt {
+	a := completedAttemptAt(id, completedAt, nil)
+	a.ExamSetID = "set1"
+	a.Mode = mode
+	a.PassingScore = 70
+	a.ScaledScore = scaledScore
+	a.Percentage = scaledScore
+	a.Passed = scaledScore >= 70
+	return a
+}
+
+func TestEstimateReadiness(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("受験が足りない場合は推定しない", func(t *testing.T) {
+		_, ok := domain.EstimateReadiness([]domain.Attempt{
+			scoredAttempt("a1", domain.ModeExam, base, 80),
+			scoredAttempt("a2", domain.ModeExam, base.AddDate(0, 0, 1), 80),
+			scoredAttempt("a3", domain.ModePractice, base.AddDate(0, 0, 2), 80),
+		})
+		assert.False(t, ok, "練習形式の受験は推定に使用しない")
+	})
+
+	t.Run("直近の受験ほど重みを大きくして推定する", func(t *testing.T) {
+		readiness, ok := domain.EstimateReadiness([]domain.Attempt{
+			scoredAttempt("a1", domain.ModeExam, base, 60),
+			scoredAttempt("a3", domain.ModeExam, base.AddDate(0, 0, 2), 80),
+			scoredAttempt("a2", domain.ModeExam, base.AddDate(0, 0, 1), 70),
+		})
+
+		assert.True(t, ok)
+		assert.Equal(t, 3, readiness.AttemptCount)
+		assert.Equal(t, 2.9, readiness.EffectiveAttempts)
+		assert.Equal(t, 70, readiness.PassingScore)
+		assert.Equal(t, 71.5, readiness.ExpectedScore)
+		assert.Equal(t, 8.1, readiness.StdDev)
+		assert.InDelta(t, 0.573, readiness.PassProbability, 0.002)
+		assert.Less(t, readiness.LowerBound, readiness.PassProbability)
+		assert.Greater(t, readiness.UpperBound, readiness.PassProbability)
+	})
+}
+
+func TestGetScoreTrend(t *testing.T) {
+	ctx := context.Background()
+	userID := "user123"
+	examID := "cloud-digital-leader"
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockAttemptRepo.On("FindByUser", ctx, mock.Anything).Return([]domain.Attempt{
+		scoredAttempt("a2", domain.ModePractice, base.AddDate(0, 0, 2), 90),
+		scoredAttempt("a1", domain.ModeExam, base.AddDate(0, 0, 1), 65),
+	}, "", nil)
+	usecase := NewStatsUsecase(new(MockUserStatsRepository), mockAttemptRepo)
+
+	in, _ := input.NewGetUserExamStats(userID, examID)
+	trend, err := usecase.GetScoreTrend(ctx, in)
+
+	assert.NoError(t, err)
+	assert.Nil(t, trend.Readiness)
+	if assert.Len(t, trend.Points, 2) {
+		assert.Equal(t, "a1", trend.Points[0].AttemptID)
+		assert.False(t, trend.Points[0].CountsTowardReadiness, "推定に必要な受験が足りない場合はどの受験も推定に使用しない")
+		assert.Equal(t, "a2", trend.Points[1].AttemptID)
+		assert.False(t, trend.Points[1].CountsTowardReadiness)
+	}
+}
+
+func TestGetScoreTrend_CountsOnlyReadinessWindow(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	attempts := []domain.Attempt{scoredAttempt("practice", domain.ModePractice, base, 90)}
+	for i := range domain.ReadinessWindow + 2 {
+		attempts = append(attempts, scoredAttempt(fmt.Sprintf("exam-%02d", i), domain.ModeExam, base.AddDate(0, 0, i+1), 75))
+	}
+	mockAttemptRepo := new(MockAttemptRepository)
+	mockAttemptRepo.On("FindByUser", ctx, mock.Anything).Return(attempts, "", nil)
+	usecase := NewStatsUsecase(new(MockUserStatsRepository), mockAttemptRepo)
+
+	in, _ := input.NewGetUserExamStats("user123", "cloud-digital-leader")
+	trend, err := usecase.GetScoreTrend(ctx, in)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, trend.Readiness) {
+		assert.Equal(t, domain.ReadinessWindow, trend.Readiness.AttemptCount)
+	}
+	counted := lo.FilterMap(trend.Points, func(p output.ScoreTrendPoint, _ int) (string, bool) {
+		return p.AttemptID, p.CountsTowardReadiness
+	})
+	// 練習形式の受験と、直近 domain.ReadinessWindow 件より古い受験は推定に使用しない
+	assert.Len(t, counted, domain.ReadinessWindow)
+	assert.NotContains(t, counted, "practice")
+	assert.NotContains(t, counted, "exam-00")
+	assert.NotContains(t, counted, "exam-01")
+}